@@ -0,0 +1,156 @@
+package mimeheader
+
+import "strconv"
+
+// Error messages of AcceptBuilder.
+const (
+	AcceptBuilderRangeErrMsg     = "invalid media range"
+	AcceptBuilderQualityErrMsg   = "quality is out of [0, 1] range"
+	AcceptBuilderDuplicateErrMsg = "duplicate media ranges"
+	AcceptBuilderShadowedErrMsg  = "shadowed media ranges with the same quality"
+	AcceptBuilderFallbackErrMsg  = "fallback is not a wildcard range"
+)
+
+type AcceptBuilderErr struct {
+	Err error
+	Msg string
+	// Range is the rejected media range.
+	Range string
+	// Other is the added media range which duplicates or shadows the rejected one.
+	Other string
+}
+
+func (e AcceptBuilderErr) Error() string {
+	msg := e.Msg + " " + strconv.Quote(e.Range)
+
+	if e.Other != "" {
+		msg += " and " + strconv.Quote(e.Other)
+	}
+
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+
+	return msg
+}
+
+func (e AcceptBuilderErr) Unwrap() error {
+	return e.Err
+}
+
+// AcceptBuilder builds Accept header of a client from media ranges it can decode, like
+//
+//	NewAcceptBuilder().Prefer("application/cbor", 1).Also("application/json", 0.8).Fallback("*/*", 0.1)
+//
+// Every range is validated, the first error stops building and is returned by AcceptBuilder.Build.
+// Qualities are rounded to three decimals the same way as AcceptHeader.String writes them,
+// positive quality below 0.001 is rounded up to it.
+// Duplicate ranges are rejected, as well as shadowed ranges: if one range matches all types of another one
+// and they have the same quality, the more specific range doesn't change quality of any type.
+type AcceptBuilder struct {
+	headers []MimeHeader
+	err     error
+}
+
+// NewAcceptBuilder creates AcceptBuilder without ranges.
+func NewAcceptBuilder() *AcceptBuilder {
+	return &AcceptBuilder{}
+}
+
+// Prefer adds the preferred media range with quality, it's the same as AcceptBuilder.Also and exists for readability.
+func (b *AcceptBuilder) Prefer(mrange string, quality float32) *AcceptBuilder {
+	return b.add(mrange, quality, false)
+}
+
+// Also adds an alternative media range with quality.
+func (b *AcceptBuilder) Also(mrange string, quality float32) *AcceptBuilder {
+	return b.add(mrange, quality, false)
+}
+
+// Fallback adds a wildcard media range with quality, like "*/*" or "application/*".
+func (b *AcceptBuilder) Fallback(mrange string, quality float32) *AcceptBuilder {
+	return b.add(mrange, quality, true)
+}
+
+// Build returns AcceptHeader with added ranges or the first error.
+func (b *AcceptBuilder) Build() (AcceptHeader, error) {
+	if b.err != nil {
+		return AcceptHeader{}, b.err
+	}
+
+	return NewAcceptHeader(append([]MimeHeader(nil), b.headers...)), nil
+}
+
+// Header returns canonical Accept header with added ranges, like AcceptHeader.String, or the first error.
+func (b *AcceptBuilder) Header() (string, error) {
+	ah, err := b.Build()
+	if err != nil {
+		return "", err
+	}
+
+	return ah.String(), nil
+}
+
+func (b *AcceptBuilder) add(mrange string, quality float32, fallback bool) *AcceptBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	mtype, err := ParseMediaType(mrange)
+	if err != nil || !mtype.Valid() {
+		b.err = AcceptBuilderErr{Err: err, Msg: AcceptBuilderRangeErrMsg, Range: mrange}
+
+		return b
+	}
+
+	if quality < 0 || quality > 1 {
+		b.err = AcceptBuilderErr{Msg: AcceptBuilderQualityErrMsg, Range: mrange}
+
+		return b
+	}
+
+	// Quality is rounded before checks, so the built ranges are the same as ranges parsed from the header.
+	quality = parseQuality(formatQValue(quality))
+
+	if fallback && mtype.Subtype != MimeAny {
+		b.err = AcceptBuilderErr{Msg: AcceptBuilderFallbackErrMsg, Range: mrange}
+
+		return b
+	}
+
+	mh := MimeHeader{MimeType: mtype, Quality: quality}
+
+	for _, added := range b.headers {
+		if err := checkAddedRange(mh, added, mrange); err != nil {
+			b.err = err
+
+			return b
+		}
+	}
+
+	b.headers = append(b.headers, mh)
+
+	return b
+}
+
+// checkAddedRange checks that the new range doesn't duplicate the added one and they don't shadow each other.
+func checkAddedRange(mh, added MimeHeader, mrange string) error {
+	covers := coversRange(added.MimeType, mh.MimeType)
+	covered := coversRange(mh.MimeType, added.MimeType)
+
+	switch {
+	case covers && covered:
+		return AcceptBuilderErr{Msg: AcceptBuilderDuplicateErrMsg, Range: mrange, Other: added.StringWithParams()}
+	case (covers || covered) && added.Quality == mh.Quality:
+		return AcceptBuilderErr{Msg: AcceptBuilderShadowedErrMsg, Range: mrange, Other: added.StringWithParams()}
+	default:
+		return nil
+	}
+}
+
+// coversRange reports whether range a matches all types matched by range b.
+func coversRange(a, b MimeType) bool {
+	return (a.Type == MimeAny || a.Type == b.Type) &&
+		(a.Subtype == MimeAny || a.Subtype == b.Subtype) &&
+		subsetParams(a.Params, b.Params)
+}
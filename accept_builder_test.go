@@ -0,0 +1,147 @@
+package mimeheader_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleAcceptBuilder() {
+	header, err := mimeheader.NewAcceptBuilder().
+		Prefer("application/cbor", 1).
+		Also("application/json", 0.8).
+		Fallback("*/*", 0.1).
+		Header()
+	fmt.Println(header, err)
+
+	_, err = mimeheader.NewAcceptBuilder().
+		Prefer("application/json", 1).
+		Also("application/*", 1).
+		Header()
+	fmt.Println(err)
+	// Output:
+	// application/cbor, application/json;q=0.8, */*;q=0.1 <nil>
+	// shadowed media ranges with the same quality "application/*" and "application/json"
+}
+
+func TestAcceptBuilder(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerAcceptBuilder() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			ah, err := prov.build(mimeheader.NewAcceptBuilder()).Build()
+
+			var builderErr mimeheader.AcceptBuilderErr
+			if errors.As(err, &builderErr) != (prov.expErrMsg != "") || builderErr.Msg != prov.expErrMsg {
+				t.Fatalf("Unexpected error.\nExpected: %s\nActual: %v", prov.expErrMsg, err)
+			}
+
+			if act := ah.String(); act != prov.exp {
+				t.Fatalf("Wrong header.\nExpected: %s\nActual: %s", prov.exp, act)
+			}
+
+			if !reflect.DeepEqual(mimeheader.ParseAcceptHeader(prov.exp).String(), ah.String()) {
+				t.Fatalf("Built header is not canonical: %s", ah.String())
+			}
+
+			for i, mh := range mimeheader.ParseAcceptHeader(prov.exp).MHeaders {
+				if ah.MHeaders[i].Quality != mh.Quality {
+					t.Fatalf("Quality of built range is not the same as written one.\nExpected: %v\nActual: %v", mh.Quality, ah.MHeaders[i].Quality)
+				}
+			}
+		})
+	}
+}
+
+type acceptBuilder struct {
+	name      string
+	build     func(b *mimeheader.AcceptBuilder) *mimeheader.AcceptBuilder
+	exp       string
+	expErrMsg string
+}
+
+func providerAcceptBuilder() []acceptBuilder {
+	return []acceptBuilder{
+		{
+			name:  "Empty",
+			build: func(b *mimeheader.AcceptBuilder) *mimeheader.AcceptBuilder { return b },
+			exp:   "",
+		},
+		{
+			name: "Params",
+			build: func(b *mimeheader.AcceptBuilder) *mimeheader.AcceptBuilder {
+				return b.Prefer("text/html; level=1", 1).Also("text/html", 0.7).Also("text/html;level=2", 0.4).Fallback("text/*", 0.3)
+			},
+			exp: "text/html;level=1, text/html;q=0.7, text/html;level=2;q=0.4, text/*;q=0.3",
+		},
+		{
+			name: "Not acceptable range",
+			build: func(b *mimeheader.AcceptBuilder) *mimeheader.AcceptBuilder {
+				return b.Fallback("image/*", 1).Also("image/gif", 0)
+			},
+			exp: "image/*, image/gif;q=0",
+		},
+		{
+			name: "Quality rounding",
+			build: func(b *mimeheader.AcceptBuilder) *mimeheader.AcceptBuilder {
+				return b.Prefer("application/json", 0.8888).Also("text/plain", 0.0004).Fallback("*/*", 0)
+			},
+			exp: "application/json;q=0.889, text/plain;q=0.001, */*;q=0",
+		},
+		{
+			name: "Shadowed after rounding",
+			build: func(b *mimeheader.AcceptBuilder) *mimeheader.AcceptBuilder {
+				return b.Prefer("application/json", 0.5).Fallback("*/*", 0.5001)
+			},
+			expErrMsg: mimeheader.AcceptBuilderShadowedErrMsg,
+		},
+		{
+			name: "Invalid range",
+			build: func(b *mimeheader.AcceptBuilder) *mimeheader.AcceptBuilder {
+				return b.Prefer("application/json", 1).Also("*/json", 0.5)
+			},
+			expErrMsg: mimeheader.AcceptBuilderRangeErrMsg,
+		},
+		{
+			name: "Invalid quality",
+			build: func(b *mimeheader.AcceptBuilder) *mimeheader.AcceptBuilder {
+				return b.Prefer("application/json", 1.1)
+			},
+			expErrMsg: mimeheader.AcceptBuilderQualityErrMsg,
+		},
+		{
+			name: "Not wildcard fallback",
+			build: func(b *mimeheader.AcceptBuilder) *mimeheader.AcceptBuilder {
+				return b.Prefer("application/json", 1).Fallback("text/plain", 0.1)
+			},
+			expErrMsg: mimeheader.AcceptBuilderFallbackErrMsg,
+		},
+		{
+			name: "Duplicate",
+			build: func(b *mimeheader.AcceptBuilder) *mimeheader.AcceptBuilder {
+				return b.Prefer("application/json;v=1", 1).Also("Application/JSON; V=1", 0.5)
+			},
+			expErrMsg: mimeheader.AcceptBuilderDuplicateErrMsg,
+		},
+		{
+			name: "Shadowed by fallback",
+			build: func(b *mimeheader.AcceptBuilder) *mimeheader.AcceptBuilder {
+				return b.Prefer("application/json", 0.5).Fallback("*/*", 0.5)
+			},
+			expErrMsg: mimeheader.AcceptBuilderShadowedErrMsg,
+		},
+		{
+			name: "The first error is kept",
+			build: func(b *mimeheader.AcceptBuilder) *mimeheader.AcceptBuilder {
+				return b.Prefer("application/", 1).Also("text/plain", 2)
+			},
+			expErrMsg: mimeheader.AcceptBuilderRangeErrMsg,
+		},
+	}
+}
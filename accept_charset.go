@@ -0,0 +1,122 @@
+package mimeheader
+
+// CharsetRange structure for a charset from Accept-Charset header (RFC 9110 Sec 12.5.2).
+type CharsetRange struct {
+	Charset string
+	Quality float32
+}
+
+// Valid validates charset to be a token, like "utf-8" or "*".
+func (cr CharsetRange) Valid() bool {
+	return isToken(cr.Charset)
+}
+
+// Match matches charset with possible wildcard.
+// Comparison is case-insensitive, IANA aliases are equivalent to their charsets, like "latin1" and "ISO-8859-1".
+func (cr CharsetRange) Match(charset string) bool {
+	if cr.Charset == MimeAny {
+		return true
+	}
+
+	return equalCharsets(cr.Charset, charset)
+}
+
+// weight returns quality of the range.
+func (cr CharsetRange) weight() float32 {
+	return cr.Quality
+}
+
+// lessSpecific reports whether the range is less specific than the other one, wildcard is less specific than a charset.
+func (cr CharsetRange) lessSpecific(other CharsetRange) bool {
+	less, _ := lessAny(cr.Charset, other.Charset)
+
+	return less
+}
+
+type AcceptCharset struct {
+	Ranges []CharsetRange
+}
+
+func NewAcceptCharsetPlain(ranges []CharsetRange) AcceptCharset {
+	return AcceptCharset{Ranges: ranges}
+}
+
+func NewAcceptCharset(ranges []CharsetRange) AcceptCharset {
+	ac := AcceptCharset{Ranges: ranges}
+	sortRanges(ac.Ranges)
+
+	return ac
+}
+
+// Add charset to accept charset.
+// CharsetRange will be validated and added ONLY if valid.
+// AcceptCharset will be sorted.
+// For performance reasons better to use Set, instead of Add.
+func (ac *AcceptCharset) Add(cr CharsetRange) {
+	if !cr.Valid() {
+		return
+	}
+
+	ac.Ranges = append(ac.Ranges, cr)
+
+	sortRanges(ac.Ranges)
+}
+
+// Set all valid charsets to AcceptCharset (override old ones).
+// Sorting will be applied.
+func (ac *AcceptCharset) Set(crs []CharsetRange) {
+	ac.Ranges = validRanges(crs)
+}
+
+// Negotiate return appropriate charset for current accept list from supported charsets.
+// Quality of every charset is taken from the most specific matched range, charsets with zero quality are not acceptable.
+// The charset with the highest quality wins, on equal quality the charset matched by a range with higher precedence wins.
+// First parameter returns matched range from accept charset.
+// Second parameter returns matched supported charset.
+// Third parameter returns matched supported charset or default charset applied.
+func (ac AcceptCharset) Negotiate(charsets []string, dcharset string) (accept CharsetRange, charset string, matched bool) {
+	crid, charset := negotiateRange(ac.Ranges, charsets)
+	if crid >= 0 {
+		return ac.Ranges[crid], charset, true
+	}
+
+	return CharsetRange{}, dcharset, false
+}
+
+// NegotiateMedia is the same function as AcceptCharset.Negotiate,
+// but it also respects charset parameter of the media range negotiated by AcceptHeader.Negotiate, like "text/html;charset=utf-8".
+// If the media range has charset parameter, only supported charsets equal to it are acceptable.
+// Empty AcceptCharset (Accept-Charset header is not sent) does not restrict charset of the media range,
+// the charset parameter is returned as the accept range with default quality in this case.
+func (ac AcceptCharset) NegotiateMedia(mh MimeHeader, charsets []string, dcharset string) (accept CharsetRange, charset string, matched bool) {
+	mcharset, ok := mh.Params[CharsetParam]
+	if !ok {
+		return ac.Negotiate(charsets, dcharset)
+	}
+
+	mcharsets := make([]string, 0, 1)
+
+	for _, scharset := range charsets {
+		if equalCharsets(mcharset, scharset) {
+			mcharsets = append(mcharsets, scharset)
+		}
+	}
+
+	if len(ac.Ranges) > 0 {
+		return ac.Negotiate(mcharsets, dcharset)
+	}
+
+	if len(mcharsets) > 0 {
+		return CharsetRange{Charset: mcharset, Quality: DefaultQuality}, mcharsets[0], true
+	}
+
+	return CharsetRange{}, dcharset, false
+}
+
+// Match is the same function as AcceptCharset.Negotiate.
+// It implements simplified interface to match only one charset and return only matched or not information.
+func (ac AcceptCharset) Match(charset string) bool {
+	_, _, matched := ac.Negotiate([]string{charset}, "")
+
+	return matched
+}
@@ -0,0 +1,205 @@
+package mimeheader_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleAcceptCharset_Negotiate() {
+	ac := mimeheader.ParseAcceptCharset("latin1;q=0.5, utf-8")
+
+	fmt.Println(ac.Negotiate([]string{"ISO-8859-1", "UTF-8"}, "US-ASCII"))
+	fmt.Println(ac.Negotiate([]string{"ISO-8859-1"}, "US-ASCII"))
+	fmt.Println(ac.Negotiate([]string{"windows-1252"}, "US-ASCII"))
+	// Output:
+	// {utf-8 1} UTF-8 true
+	// {latin1 0.5} ISO-8859-1 true
+	// { 0} US-ASCII false
+}
+
+func ExampleAcceptCharset_NegotiateMedia() {
+	ah := mimeheader.ParseAcceptHeader("text/html;charset=latin1, application/json")
+	ac := mimeheader.ParseAcceptCharset("utf-8, iso-8859-1;q=0.5")
+
+	mh, _, _ := ah.Negotiate([]string{"text/html"}, "")
+	fmt.Println(ac.NegotiateMedia(mh, []string{"UTF-8", "ISO-8859-1"}, "UTF-8"))
+
+	mh, _, _ = ah.Negotiate([]string{"application/json"}, "")
+	fmt.Println(ac.NegotiateMedia(mh, []string{"UTF-8", "ISO-8859-1"}, "UTF-8"))
+	// Output:
+	// {iso-8859-1 0.5} ISO-8859-1 true
+	// {utf-8 1} UTF-8 true
+}
+
+func TestAcceptCharset_Negotiate(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerAcceptCharsetNegotiate() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			ac := mimeheader.ParseAcceptCharset(prov.header)
+
+			actRange, actCharset, actMatched := ac.Negotiate(prov.charsets, prov.dcharset)
+			if actRange != prov.expRange {
+				t.Errorf("Wrong range matched.\nExpected: %v\nActual: %v", prov.expRange, actRange)
+			}
+
+			if actCharset != prov.expCharset {
+				t.Errorf("Wrong charset returned.\nExpected: %s\nActual: %s", prov.expCharset, actCharset)
+			}
+
+			if actMatched != prov.expMatched {
+				t.Errorf("Unepected match result.\nExpected: %t\nActual: %t", prov.expMatched, actMatched)
+			}
+		})
+	}
+}
+
+func TestAcceptCharset_NegotiateMedia(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerAcceptCharsetNegotiateMedia() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			ac := mimeheader.ParseAcceptCharset(prov.header)
+
+			mtype, err := mimeheader.ParseMediaType(prov.mrange)
+			if err != nil {
+				t.Fatalf("Unexpected media range error: %v", err)
+			}
+
+			mh := mimeheader.MimeHeader{MimeType: mtype, Quality: mimeheader.DefaultQuality}
+
+			actRange, actCharset, actMatched := ac.NegotiateMedia(mh, prov.charsets, prov.dcharset)
+			if actRange != prov.expRange {
+				t.Errorf("Wrong range matched.\nExpected: %v\nActual: %v", prov.expRange, actRange)
+			}
+
+			if actCharset != prov.expCharset {
+				t.Errorf("Wrong charset returned.\nExpected: %s\nActual: %s", prov.expCharset, actCharset)
+			}
+
+			if actMatched != prov.expMatched {
+				t.Errorf("Unepected match result.\nExpected: %t\nActual: %t", prov.expMatched, actMatched)
+			}
+		})
+	}
+}
+
+type acceptCharsetNegotiate struct {
+	name       string
+	header     string
+	mrange     string
+	charsets   []string
+	dcharset   string
+	expRange   mimeheader.CharsetRange
+	expCharset string
+	expMatched bool
+}
+
+func providerAcceptCharsetNegotiate() []acceptCharsetNegotiate {
+	return []acceptCharsetNegotiate{
+		{
+			name:       "Empty header",
+			header:     "",
+			charsets:   []string{"utf-8"},
+			dcharset:   "utf-8",
+			expCharset: "utf-8",
+			expMatched: false,
+		},
+		{
+			name:       "Alias",
+			header:     "l1",
+			charsets:   []string{"utf-8", "iso_8859-1"},
+			dcharset:   "utf-8",
+			expRange:   mimeheader.CharsetRange{Charset: "l1", Quality: 1},
+			expCharset: "iso_8859-1",
+			expMatched: true,
+		},
+		{
+			name:       "Wildcard matches not mentioned charsets only",
+			header:     "*;q=0.3, utf-8;q=0",
+			charsets:   []string{"utf-8", "koi8-r"},
+			dcharset:   "us-ascii",
+			expRange:   mimeheader.CharsetRange{Charset: "*", Quality: 0.3},
+			expCharset: "koi8-r",
+			expMatched: true,
+		},
+		{
+			name:       "Wildcard excluded",
+			header:     "*;q=0",
+			charsets:   []string{"utf-8"},
+			dcharset:   "us-ascii",
+			expCharset: "us-ascii",
+			expMatched: false,
+		},
+		{
+			name:       "Highest quality wins regardless of charsets order",
+			header:     "utf-16;q=0.2, utf-8;q=0.9",
+			charsets:   []string{"utf-16", "utf-8"},
+			dcharset:   "us-ascii",
+			expRange:   mimeheader.CharsetRange{Charset: "utf-8", Quality: 0.9},
+			expCharset: "utf-8",
+			expMatched: true,
+		},
+	}
+}
+
+func providerAcceptCharsetNegotiateMedia() []acceptCharsetNegotiate {
+	return []acceptCharsetNegotiate{
+		{
+			name:       "Media range without charset",
+			header:     "utf-8",
+			mrange:     "text/html",
+			charsets:   []string{"latin1", "utf-8"},
+			dcharset:   "us-ascii",
+			expRange:   mimeheader.CharsetRange{Charset: "utf-8", Quality: 1},
+			expCharset: "utf-8",
+			expMatched: true,
+		},
+		{
+			name:       "Media range charset without header",
+			header:     "",
+			mrange:     "text/html;charset=UTF8",
+			charsets:   []string{"latin1", "utf-8"},
+			dcharset:   "us-ascii",
+			expRange:   mimeheader.CharsetRange{Charset: "UTF8", Quality: 1},
+			expCharset: "utf-8",
+			expMatched: true,
+		},
+		{
+			name:       "Media range charset is not supported",
+			header:     "",
+			mrange:     "text/html;charset=koi8-r",
+			charsets:   []string{"latin1", "utf-8"},
+			dcharset:   "us-ascii",
+			expCharset: "us-ascii",
+			expMatched: false,
+		},
+		{
+			name:       "Media range charset is excluded by header",
+			header:     "*, latin1;q=0",
+			mrange:     "text/html;charset=iso-8859-1",
+			charsets:   []string{"latin1", "utf-8"},
+			dcharset:   "us-ascii",
+			expCharset: "us-ascii",
+			expMatched: false,
+		},
+		{
+			name:       "Media range charset restricts header",
+			header:     "utf-8, latin1;q=0.1",
+			mrange:     "text/html;charset=iso-8859-1",
+			charsets:   []string{"utf-8", "latin1"},
+			dcharset:   "us-ascii",
+			expRange:   mimeheader.CharsetRange{Charset: "latin1", Quality: 0.1},
+			expCharset: "latin1",
+			expMatched: true,
+		},
+	}
+}
@@ -0,0 +1,156 @@
+package mimeheader
+
+import "strings"
+
+// Content codings registered in HTTP Content Coding Registry.
+const (
+	EncodingIdentity = "identity"
+	EncodingGzip     = "gzip"
+	EncodingDeflate  = "deflate"
+	EncodingCompress = "compress"
+	EncodingBrotli   = "br"
+	EncodingZstd     = "zstd"
+)
+
+// EncodingRange structure for a content coding from Accept-Encoding header (RFC 9110 Sec 12.5.3).
+type EncodingRange struct {
+	Coding  string
+	Quality float32
+}
+
+// Valid validates coding to be a token, like "gzip" or "*".
+func (er EncodingRange) Valid() bool {
+	return isToken(er.Coding)
+}
+
+// Match matches content coding with possible wildcard.
+// Comparison is case-insensitive, "x-gzip" and "x-compress" are equivalent to "gzip" and "compress" (RFC 9110 Sec 8.4.1).
+func (er EncodingRange) Match(coding string) bool {
+	if er.Coding == MimeAny {
+		return true
+	}
+
+	return canonicalCoding(er.Coding) == canonicalCoding(coding)
+}
+
+// weight returns quality of the range.
+func (er EncodingRange) weight() float32 {
+	return er.Quality
+}
+
+// lessSpecific reports whether the range is less specific than the other one, wildcard is less specific than a coding.
+func (er EncodingRange) lessSpecific(other EncodingRange) bool {
+	less, _ := lessAny(er.Coding, other.Coding)
+
+	return less
+}
+
+type AcceptEncoding struct {
+	Ranges []EncodingRange
+}
+
+func NewAcceptEncodingPlain(ranges []EncodingRange) AcceptEncoding {
+	return AcceptEncoding{Ranges: ranges}
+}
+
+func NewAcceptEncoding(ranges []EncodingRange) AcceptEncoding {
+	ae := AcceptEncoding{Ranges: ranges}
+	sortRanges(ae.Ranges)
+
+	return ae
+}
+
+// Add coding to accept encoding.
+// EncodingRange will be validated and added ONLY if valid.
+// AcceptEncoding will be sorted.
+// For performance reasons better to use Set, instead of Add.
+func (ae *AcceptEncoding) Add(er EncodingRange) {
+	if !er.Valid() {
+		return
+	}
+
+	ae.Ranges = append(ae.Ranges, er)
+
+	sortRanges(ae.Ranges)
+}
+
+// Set all valid codings to AcceptEncoding (override old ones).
+// Sorting will be applied.
+func (ae *AcceptEncoding) Set(ers []EncodingRange) {
+	ae.Ranges = validRanges(ers)
+}
+
+// Negotiate return appropriate content coding for current accept list from supported codings.
+// Quality of every coding is taken from the most specific matched range, codings with zero quality are not acceptable.
+// The coding with the highest quality wins, on equal quality the coding matched by a range with higher precedence wins.
+// Identity is always supported and does not need to be in the list. It is acceptable, even if not mentioned in the header,
+// unless excluded by "identity;q=0" or by "*;q=0" without more specific range for identity (RFC 9110 Sec 12.5.3).
+// Implicitly acceptable identity is used only when no other coding matched, accept range is empty in this case.
+// First parameter returns matched range from accept encoding.
+// Second parameter returns matched supported coding.
+// Third parameter returns false if nothing is acceptable, 406 Not Acceptable response can be sent in this case.
+func (ae AcceptEncoding) Negotiate(codings []string) (accept EncodingRange, coding string, matched bool) {
+	erid := -1
+	identity := true
+
+	// Identity is checked after all supported codings, because it is always supported.
+	for cid := 0; cid <= len(codings); cid++ {
+		scoding := EncodingIdentity
+		if cid < len(codings) {
+			scoding = codings[cid]
+		}
+
+		id := mostSpecificRange(ae.Ranges, scoding)
+		if id < 0 {
+			continue
+		}
+
+		if canonicalCoding(scoding) == EncodingIdentity {
+			identity = false
+		}
+
+		if ae.Ranges[id].Quality <= 0 {
+			continue
+		}
+
+		if erid < 0 || preferredWeighted(ae.Ranges, id, erid) {
+			erid = id
+			coding = scoding
+		}
+	}
+
+	if erid >= 0 {
+		return ae.Ranges[erid], coding, true
+	}
+
+	if identity {
+		return EncodingRange{}, EncodingIdentity, true
+	}
+
+	return EncodingRange{}, "", false
+}
+
+// Match reports whether the coding is acceptable by the same rules as AcceptEncoding.Negotiate.
+// Implicitly acceptable identity is matched as well.
+func (ae AcceptEncoding) Match(coding string) bool {
+	id := mostSpecificRange(ae.Ranges, coding)
+	if id < 0 {
+		return canonicalCoding(coding) == EncodingIdentity
+	}
+
+	return ae.Ranges[id].Quality > 0
+}
+
+// canonicalCoding returns lower cased coding with resolved aliases.
+func canonicalCoding(coding string) string {
+	coding = strings.ToLower(coding)
+
+	switch coding {
+	case "x-gzip":
+		return EncodingGzip
+	case "x-compress":
+		return EncodingCompress
+	default:
+		return coding
+	}
+}
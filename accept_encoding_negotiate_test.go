@@ -0,0 +1,140 @@
+package mimeheader_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleAcceptEncoding_Negotiate() {
+	ae := mimeheader.ParseAcceptEncoding("br;q=1.0, gzip;q=0.8, *;q=0")
+
+	fmt.Println(ae.Negotiate([]string{"zstd", "gzip", "br"}))
+	fmt.Println(ae.Negotiate([]string{"zstd", "gzip"}))
+	fmt.Println(ae.Negotiate([]string{"zstd"}))
+	// Output:
+	// {br 1} br true
+	// {gzip 0.8} gzip true
+	// { 0}  false
+}
+
+func TestAcceptEncoding_Negotiate(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerAcceptEncodingNegotiate() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			ae := mimeheader.ParseAcceptEncoding(prov.header)
+
+			actRange, actCoding, actMatched := ae.Negotiate(prov.codings)
+			if actRange != prov.expRange {
+				t.Errorf("Wrong range matched.\nExpected: %v\nActual: %v", prov.expRange, actRange)
+			}
+
+			if actCoding != prov.expCoding {
+				t.Errorf("Wrong coding returned.\nExpected: %s\nActual: %s", prov.expCoding, actCoding)
+			}
+
+			if actMatched != prov.expMatched {
+				t.Errorf("Unepected match result.\nExpected: %t\nActual: %t", prov.expMatched, actMatched)
+			}
+
+			if ae.Match(prov.expCoding) != prov.expMatched {
+				t.Errorf("Negotiated coding must be matched.\nExpected: %t\nActual: %t", prov.expMatched, !prov.expMatched)
+			}
+		})
+	}
+}
+
+type acceptEncodingNegotiate struct {
+	name       string
+	header     string
+	codings    []string
+	expRange   mimeheader.EncodingRange
+	expCoding  string
+	expMatched bool
+}
+
+func providerAcceptEncodingNegotiate() []acceptEncodingNegotiate {
+	return []acceptEncodingNegotiate{
+		{
+			name:       "Empty header allows identity only",
+			header:     "",
+			codings:    []string{"gzip", "br"},
+			expCoding:  "identity",
+			expMatched: true,
+		},
+		{
+			name:       "Implicit identity",
+			header:     "gzip",
+			codings:    []string{"br"},
+			expCoding:  "identity",
+			expMatched: true,
+		},
+		{
+			name:       "Implicit identity has less priority than explicit coding",
+			header:     "gzip;q=0.1",
+			codings:    []string{"identity", "gzip"},
+			expRange:   mimeheader.EncodingRange{Coding: "gzip", Quality: 0.1},
+			expCoding:  "gzip",
+			expMatched: true,
+		},
+		{
+			name:       "Explicit identity",
+			header:     "gzip;q=0.5, identity",
+			codings:    []string{"gzip"},
+			expRange:   mimeheader.EncodingRange{Coding: "identity", Quality: 1},
+			expCoding:  "identity",
+			expMatched: true,
+		},
+		{
+			name:       "Identity excluded",
+			header:     "gzip, identity;q=0",
+			codings:    []string{"br"},
+			expCoding:  "",
+			expMatched: false,
+		},
+		{
+			name:       "Identity excluded by wildcard",
+			header:     "*;q=0",
+			codings:    []string{"gzip", "br"},
+			expCoding:  "",
+			expMatched: false,
+		},
+		{
+			name:       "Identity allowed by more specific range than wildcard",
+			header:     "*;q=0, identity;q=0.2",
+			codings:    []string{"gzip"},
+			expRange:   mimeheader.EncodingRange{Coding: "identity", Quality: 0.2},
+			expCoding:  "identity",
+			expMatched: true,
+		},
+		{
+			name:       "Wildcard matches not mentioned codings only",
+			header:     "*, gzip;q=0",
+			codings:    []string{"gzip", "zstd"},
+			expRange:   mimeheader.EncodingRange{Coding: "*", Quality: 1},
+			expCoding:  "zstd",
+			expMatched: true,
+		},
+		{
+			name:       "Case insensitive with aliases",
+			header:     "X-GZIP",
+			codings:    []string{"br", "gzip"},
+			expRange:   mimeheader.EncodingRange{Coding: "X-GZIP", Quality: 1},
+			expCoding:  "gzip",
+			expMatched: true,
+		},
+		{
+			name:       "Equal quality resolved by header order",
+			header:     "gzip, br",
+			codings:    []string{"br", "gzip"},
+			expRange:   mimeheader.EncodingRange{Coding: "gzip", Quality: 1},
+			expCoding:  "gzip",
+			expMatched: true,
+		},
+	}
+}
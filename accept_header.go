@@ -1,10 +1,18 @@
 package mimeheader
 
-import "sort"
-
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MimeHeader structure for a media range of Accept header (RFC 9110 Sec 12.5.1).
+// MimeType.Params contains only media type parameters placed before the weight, they are used for matching and sorting.
+// Parameters placed after the weight are accept extensions, they are stored in Extensions.
 type MimeHeader struct {
 	MimeType
-	Quality float32
+	Quality    float32
+	Extensions map[string]string
 }
 
 type AcceptHeader struct {
@@ -34,52 +42,59 @@ func (ah AcceptHeader) Less(i, j int) bool {
 		return ah.MHeaders[i].Quality < ah.MHeaders[j].Quality
 	}
 
-	less, done := ah.lessWildcard(i, j)
-	if done {
-		return less
-	}
-
-	return ah.lessParams(i, j)
+	return ah.lessSpecific(i, j)
 }
 
-func (ah AcceptHeader) lessParams(i, j int) bool {
-	li := len(ah.MHeaders[i].Params)
-	_, ok := ah.MHeaders[i].Params["q"]
+// lessSpecific reports whether range i is less specific than range j.
+// Wildcards are less specific than exact types, then ranges with fewer params are less specific.
+func (ah AcceptHeader) lessSpecific(i, j int) bool {
+	return lessSpecificRange(ah.MHeaders[i].specificity(false), ah.MHeaders[j].specificity(false))
+}
 
-	if ok {
-		li--
-	}
+// rangeSpecificity contains parts of a media range which define its specificity.
+type rangeSpecificity struct {
+	typ     string
+	subtype string
+	// suffix reports whether the range matched an offered type by the structured syntax suffix.
+	suffix  bool
+	nparams int
+}
 
-	lj := len(ah.MHeaders[j].Params)
-	_, ok = ah.MHeaders[j].Params["q"]
+// specificity returns specificity of the media type used as a range.
+func (mt MimeType) specificity(suffix bool) rangeSpecificity {
+	return rangeSpecificity{typ: mt.Type, subtype: mt.Subtype, suffix: suffix, nparams: len(mt.Params)}
+}
 
-	if ok {
-		lj--
+// lessSpecificRange reports whether range a is less specific than range b.
+// Wildcards are less specific than exact types, then a match by the structured syntax suffix
+// is less specific than a match of the exact subtype, then ranges with fewer params are less specific.
+func lessSpecificRange(a, b rangeSpecificity) bool {
+	less, done := lessAny(a.typ, b.typ)
+	if done {
+		return less
 	}
 
-	return li < lj
-}
-
-func (ah AcceptHeader) lessWildcard(i, j int) (less, done bool) {
-	// '*' value has less priority than a specific type
-	// If i contains '*' and j has specific type, then i less than j
-	if ah.MHeaders[i].Type == MimeAny && ah.MHeaders[j].Type != MimeAny {
-		return true, true
+	less, done = lessAny(a.subtype, b.subtype)
+	if done {
+		return less
 	}
 
-	// If i contains a specific type and j contains '*' then i greater than j
-	if ah.MHeaders[i].Type != MimeAny && ah.MHeaders[j].Type == MimeAny {
-		return false, true
+	if a.suffix != b.suffix {
+		return a.suffix
 	}
 
-	// '*' value has less priority than a specific type
-	// If i contains '*' and j has specific type, then i less than j
-	if ah.MHeaders[i].Subtype == MimeAny && ah.MHeaders[j].Subtype != MimeAny {
+	return a.nparams < b.nparams
+}
+
+// lessAny compares two values where '*' value has less priority than a specific one.
+// If i contains '*' and j has specific value, then i less than j.
+// If i contains a specific value and j contains '*' then i greater than j.
+func lessAny(i, j string) (less, done bool) {
+	if i == MimeAny && j != MimeAny {
 		return true, true
 	}
 
-	// If i contains a specific type and j contains '*' then i greater than j
-	if ah.MHeaders[i].Subtype != MimeAny && ah.MHeaders[j].Subtype == MimeAny {
+	if i != MimeAny && j == MimeAny {
 		return false, true
 	}
 
@@ -121,48 +136,324 @@ func (ah *AcceptHeader) Set(mhs []MimeHeader) {
 	ah.sort()
 }
 
-// Negotiate return appropriate type fot current accept list from supported (common) mime types.
-// First parameter returns matched value from accept header.
-// Second parameter returns matched common type.
-// Third parameter returns matched common type or default type applied.
-func (ah AcceptHeader) Negotiate(ctypes []string, dtype string) (accept MimeHeader, mimeType string, matched bool) {
-	if len(ctypes) == 0 || len(ah.MHeaders) == 0 {
-		return MimeHeader{}, dtype, false
+// NegotiationResult is a result of mime type negotiation.
+type NegotiationResult struct {
+	// Offer is the offered (common) type as it was passed to negotiation or the default type if nothing matched.
+	Offer string
+	// MimeType is the parsed offered type with params, except of source quality param.
+	// It's the parsed default type if nothing matched, zero value is used for invalid default type.
+	MimeType MimeType
+	// Accept is the most specific range of accept header matched the offered type.
+	Accept MimeHeader
+	// Quality is an effective quality of the offered type: quality of the range multiplied by source quality.
+	Quality float32
+	// Default reports whether none of offered types is acceptable and the default type is used.
+	Default bool
+}
+
+// NegotiateResult return appropriate type for current accept list from supported (common) mime types.
+// Quality of every common type is taken from the most specific matched range of the accept header,
+// types with zero quality are not acceptable (RFC 9110 Sec 12.5.1).
+// Common type can set server-side source quality by "qs" param, like "image/png;qs=0.7",
+// then quality of the type is multiplied by it. The param is not used for matching.
+// Types with "qs" value which doesn't match qvalue grammar, like "qs=5" or "qs=abc", are skipped as invalid.
+// The type with the highest quality wins, on equal quality the type matched by a range with higher precedence wins.
+// Default type is returned if none of common types is acceptable.
+// Params of ranges are ignored by default, modes have the same meaning as for MimeType.Match.
+func (ah AcceptHeader) NegotiateResult(ctypes []string, dtype string, modes ...MatchMode) NegotiationResult {
+	res, oid := ah.negotiate(ctypes, mergeModes(modes))
+	if oid < 0 {
+		return defaultResult(dtype)
 	}
 
+	return res
+}
+
+// negotiate returns negotiation result and index of the negotiated common type or -1 if nothing matched.
+func (ah AcceptHeader) negotiate(ctypes []string, mode MatchMode) (NegotiationResult, int) {
 	var parsedCType MimeType
 
-	mhid := -1
+	best, oid := rangeMatch{hid: -1}, -1
 
-	for _, ctype := range ctypes {
-		for hid, header := range ah.MHeaders {
-			mtype, err := ParseMediaType(ctype)
-			if err != nil {
-				continue
-			}
-
-			if header.Match(mtype) && (mhid > hid || mhid < 0) {
-				parsedCType = mtype
-				mhid = hid
-			}
+	for id, ctype := range ctypes {
+		om, err := ah.matchOffer(ctype, mode)
+		if err != nil || !om.acceptable() {
+			continue
+		}
+
+		if best.hid < 0 || ah.preferred(om.rangeMatch, best) {
+			parsedCType, best, oid = om.mtype, om.rangeMatch, id
 		}
 	}
 
-	if mhid >= 0 {
-		return ah.MHeaders[mhid], parsedCType.String(), true
+	if oid < 0 {
+		return NegotiationResult{}, -1
 	}
 
-	return MimeHeader{}, dtype, false
+	res := NegotiationResult{
+		Offer:    ctypes[oid],
+		MimeType: parsedCType,
+		Accept:   ah.MHeaders[best.hid],
+		Quality:  best.quality,
+	}
+
+	return res, oid
+}
+
+// Negotiate return appropriate type fot current accept list from supported (common) mime types
+// by the same rules as AcceptHeader.NegotiateResult.
+// First parameter returns matched value from accept header.
+// Second parameter returns matched common type without params.
+// Third parameter returns matched common type or default type applied.
+func (ah AcceptHeader) Negotiate(ctypes []string, dtype string, modes ...MatchMode) (accept MimeHeader, mimeType string, matched bool) {
+	res := ah.NegotiateResult(ctypes, dtype, modes...)
+	if res.Default {
+		return MimeHeader{}, dtype, false
+	}
+
+	return res.Accept, res.MimeType.String(), true
 }
 
 // Match is the same function as AcceptHeader.Negotiate.
 // It implements simplified interface to match only one type and return only matched or not information.
-func (ah AcceptHeader) Match(mtype string) bool {
-	_, _, matched := ah.Negotiate([]string{mtype}, "")
+func (ah AcceptHeader) Match(mtype string, modes ...MatchMode) bool {
+	_, _, matched := ah.Negotiate([]string{mtype}, "", modes...)
 
 	return matched
 }
 
+// Rank returns all acceptable offered (common) types ordered by precedence.
+// Types are ordered by the same rules as AcceptHeader.NegotiateResult chooses them, so the first one is the negotiated type.
+// Types with equal precedence keep order of offered types. Invalid and not acceptable types are skipped.
+// Modes have the same meaning as for MimeType.Match.
+func (ah AcceptHeader) Rank(ctypes []string, modes ...MatchMode) []NegotiationResult {
+	type rankedMatch struct {
+		ctype string
+		mtype MimeType
+		rm    rangeMatch
+	}
+
+	mode := mergeModes(modes)
+	matches := make([]rankedMatch, 0, len(ctypes))
+
+	for _, ctype := range ctypes {
+		om, err := ah.matchOffer(ctype, mode)
+		if err == nil && om.acceptable() {
+			matches = append(matches, rankedMatch{ctype: ctype, mtype: om.mtype, rm: om.rangeMatch})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return ah.preferred(matches[i].rm, matches[j].rm)
+	})
+
+	ranked := make([]NegotiationResult, 0, len(matches))
+	for _, m := range matches {
+		ranked = append(ranked, NegotiationResult{
+			Offer:    m.ctype,
+			MimeType: m.mtype,
+			Accept:   ah.MHeaders[m.rm.hid],
+			Quality:  m.rm.quality,
+		})
+	}
+
+	return ranked
+}
+
+// defaultResult returns negotiation result with the default type.
+func defaultResult(dtype string) NegotiationResult {
+	res := NegotiationResult{Offer: dtype, Default: true}
+
+	if mtype, err := ParseMediaType(dtype); err == nil {
+		// Source quality of the default type is not used.
+		_, _ = cutSourceQuality(mtype)
+
+		res.MimeType = mtype
+	}
+
+	return res
+}
+
+// matchOffer parses the offered type and matches it with the most specific range.
+// Error is returned if the type or its source quality is invalid.
+// Effective quality of the match is quality of the range multiplied by source quality of the type.
+func (ah AcceptHeader) matchOffer(ctype string, mode MatchMode) (offerMatch, error) {
+	mtype, err := ParseMediaType(ctype)
+	if err != nil {
+		return offerMatch{}, err
+	}
+
+	qs, err := cutSourceQuality(mtype)
+	if err != nil {
+		return offerMatch{}, err
+	}
+
+	om := offerMatch{rangeMatch: ah.mostSpecific(mtype, mode), mtype: mtype, qs: qs}
+	om.quality *= qs
+
+	return om, nil
+}
+
+// offerMatch is an offered type matched by the most specific range of accept header.
+type offerMatch struct {
+	rangeMatch
+	// mtype is the parsed offered type without "qs" param.
+	mtype MimeType
+	// qs is source quality of the offered type.
+	qs float32
+}
+
+// rangeMatch is a range of accept header matched an offered type.
+type rangeMatch struct {
+	// hid is an index of the range or -1 if nothing matched.
+	hid int
+	// suffix reports whether the range matched by the structured syntax suffix.
+	suffix bool
+	// quality is an effective quality of the offered type.
+	quality float32
+}
+
+// acceptable reports whether the offered type matched a range with non-zero effective quality.
+func (rm rangeMatch) acceptable() bool {
+	return rm.hid >= 0 && rm.quality > 0
+}
+
+// mostSpecific returns the most specific range matched the mime type.
+// Ranges with the same specificity are resolved by their position in the list.
+func (ah AcceptHeader) mostSpecific(mtype MimeType, mode MatchMode) rangeMatch {
+	best := rangeMatch{hid: -1}
+
+	for hid, header := range ah.MHeaders {
+		matched, suffix := header.match(mtype, mode)
+		if !matched {
+			continue
+		}
+
+		rm := rangeMatch{hid: hid, suffix: suffix, quality: header.Quality}
+		if best.hid < 0 || ah.lessSpecificMatch(best, rm) {
+			best = rm
+		}
+	}
+
+	return best
+}
+
+// lessSpecificMatch reports whether range of match a is less specific than range of match b, like lessSpecificRange.
+func (ah AcceptHeader) lessSpecificMatch(a, b rangeMatch) bool {
+	return lessSpecificRange(ah.MHeaders[a.hid].specificity(a.suffix), ah.MHeaders[b.hid].specificity(b.suffix))
+}
+
+// preferred reports whether match a has higher precedence than match b.
+func (ah AcceptHeader) preferred(a, b rangeMatch) bool {
+	if a.quality != b.quality {
+		return a.quality > b.quality
+	}
+
+	if ah.lessSpecificMatch(b, a) {
+		return true
+	}
+
+	if ah.lessSpecificMatch(a, b) {
+		return false
+	}
+
+	return a.hid < b.hid
+}
+
+// String builds canonical Accept header from ranges in order of precedence, like "text/html;level=1, text/*;q=0.3".
+// Media type params and extensions are sorted by name, values are quoted if they are not tokens.
+// Quality is formatted with at most three decimals and omitted if it equals 1, except of ranges with extensions.
+// Invalid ranges are skipped.
+func (ah AcceptHeader) String() string {
+	sorted := NewAcceptHeader(append([]MimeHeader(nil), ah.MHeaders...))
+
+	var b strings.Builder
+
+	for _, mh := range sorted.MHeaders {
+		if !mh.Valid() {
+			continue
+		}
+
+		if b.Len() > 0 {
+			b.WriteString(ListSeparator + " ")
+		}
+
+		b.WriteString(mh.MimeType.String())
+		writeParams(&b, mh.Params)
+
+		if mh.Quality != DefaultQuality || len(mh.Extensions) > 0 {
+			b.WriteString(ParamSeparator + QualityParam + ParamAssign + formatQValue(mh.Quality))
+		}
+
+		writeParams(&b, mh.Extensions)
+	}
+
+	return b.String()
+}
+
+// writeParams writes params sorted by name, like ";a=1;b=2". Names are lowercased.
+func writeParams(b *strings.Builder, params map[string]string) {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		b.WriteString(ParamSeparator + strings.ToLower(name) + ParamAssign + quoteParamValue(params[name]))
+	}
+}
+
+// quoteParamValue returns the value as is if it's a token, otherwise quoted string (RFC 9110 Sec 5.6.4).
+func quoteParamValue(value string) string {
+	if isToken(value) {
+		return value
+	}
+
+	var b strings.Builder
+
+	b.Grow(len(value) + 2)
+	b.WriteByte('"')
+
+	for i := 0; i < len(value); i++ {
+		if value[i] == '"' || value[i] == '\\' {
+			b.WriteByte('\\')
+		}
+
+		b.WriteByte(value[i])
+	}
+
+	b.WriteByte('"')
+
+	return b.String()
+}
+
+// formatQValue formats quality by qvalue grammar with at most three decimals, like "1", "0.5" or "0.333".
+// Quality out of [0, 1] range is clamped, positive quality below "0.001" is rounded up to it to stay acceptable.
+func formatQValue(quality float32) string {
+	const (
+		decimals   = 3
+		floatSize  = 32
+		minQValue  = "0.001"
+		minQuality = 0.001
+	)
+
+	switch {
+	case quality <= 0:
+		return "0"
+	case quality >= 1:
+		return "1"
+	case quality < minQuality:
+		return minQValue
+	}
+
+	qvalue := strconv.FormatFloat(float64(quality), 'f', decimals, floatSize)
+	qvalue = strings.TrimRight(qvalue, "0")
+
+	return strings.TrimSuffix(qvalue, ".")
+}
+
 func (ah *AcceptHeader) sort() {
-	sort.Sort(sort.Reverse(ah))
+	sort.Stable(sort.Reverse(ah))
 }
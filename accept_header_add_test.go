@@ -12,7 +12,7 @@ func ExampleAcceptHeader_Add() {
 	ah := mimeheader.ParseAcceptHeader("image/png")
 	fmt.Println(ah.Match("application/json"))
 
-	ah.Add(mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "application", Subtype: "*"}})
+	ah.Add(mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "application", Subtype: "*"}, Quality: mimeheader.DefaultQuality})
 	fmt.Println(ah.Match("application/json"))
 	// Output:
 	// false
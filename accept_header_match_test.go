@@ -11,7 +11,7 @@ func ExampleAcceptHeader_Match() {
 	ah := mimeheader.ParseAcceptHeader("image/png")
 	fmt.Println(ah.Match("application/json"))
 
-	ah.Add(mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "application", Subtype: "*"}})
+	ah.Add(mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "application", Subtype: "*"}, Quality: mimeheader.DefaultQuality})
 	fmt.Println(ah.Match("application/json"))
 	// Output:
 	// false
@@ -80,5 +80,11 @@ func providerAcceptHeaderMatch() []acceptHeaderMatch {
 			ctype:  "application/json",
 			exp:    true,
 		},
+		{
+			name:   "Excluded by zero quality",
+			header: "application/*, application/json;q=0",
+			ctype:  "application/json",
+			exp:    false,
+		},
 	}
 }
@@ -0,0 +1,84 @@
+package mimeheader_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleAcceptHeader_NegotiateResult() {
+	ah := mimeheader.ParseAcceptHeader("text/*;q=0.8, application/json")
+
+	res := ah.NegotiateResult([]string{"text/html; charset=utf-8", "application/xml"}, "application/octet-stream")
+	fmt.Println(res.Offer, res.MimeType.StringWithParams(), res.Accept.String(), res.Quality, res.Default)
+
+	res = ah.NegotiateResult([]string{"image/png"}, "application/octet-stream")
+	fmt.Println(res.Offer, res.MimeType.StringWithParams(), res.Accept.String(), res.Quality, res.Default)
+	// Output:
+	// text/html; charset=utf-8 text/html; charset=utf-8 text/* 0.8 false
+	// application/octet-stream application/octet-stream  0 true
+}
+
+func TestAcceptHeader_NegotiateResult(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerAcceptHeaderNegotiateResult() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			act := mimeheader.ParseAcceptHeader(prov.header).NegotiateResult(prov.ctypes, prov.dtype)
+			if !reflect.DeepEqual(prov.exp, act) {
+				t.Fatalf("Wrong negotiation result.\nExpected: %+v\nActual: %+v", prov.exp, act)
+			}
+		})
+	}
+}
+
+type acceptHeaderNegotiateResult struct {
+	name   string
+	header string
+	ctypes []string
+	dtype  string
+	exp    mimeheader.NegotiationResult
+}
+
+func providerAcceptHeaderNegotiateResult() []acceptHeaderNegotiateResult {
+	return []acceptHeaderNegotiateResult{
+		{
+			name:   "Offer with params",
+			header: "text/html;q=0.5, application/json;q=0.4",
+			ctypes: []string{"application/json", "text/html;charset=utf-8;qs=0.9"},
+			dtype:  "text/plain",
+			exp: mimeheader.NegotiationResult{
+				Offer:    "text/html;charset=utf-8;qs=0.9",
+				MimeType: mimeheader.MimeType{Type: "text", Subtype: "html", Params: map[string]string{"charset": "utf-8"}},
+				Accept: mimeheader.MimeHeader{
+					MimeType: mimeheader.MimeType{Type: "text", Subtype: "html", Params: map[string]string{}},
+					Quality:  0.5,
+				},
+				Quality: 0.45,
+			},
+		},
+		{
+			name:   "Default",
+			header: "text/html",
+			ctypes: []string{"application/json"},
+			dtype:  "text/plain; charset=utf-8",
+			exp: mimeheader.NegotiationResult{
+				Offer:    "text/plain; charset=utf-8",
+				MimeType: mimeheader.MimeType{Type: "text", Subtype: "plain", Params: map[string]string{"charset": "utf-8"}},
+				Default:  true,
+			},
+		},
+		{
+			name:   "Empty default",
+			header: "",
+			ctypes: []string{"application/json"},
+			dtype:  "",
+			exp:    mimeheader.NegotiationResult{Default: true},
+		},
+	}
+}
@@ -18,10 +18,24 @@ func ExampleAcceptHeader_Negotiate() {
 	// Output:
 	// image/* image/tiff true
 	// image/png image/png true
-	// image/* image/svg true
+	// image/svg image/svg true
 	//  text/javascript false
 }
 
+func ExampleAcceptHeader_Negotiate_sourceQuality() {
+	ah := mimeheader.ParseAcceptHeader("image/png, application/pdf")
+
+	// The client is indifferent, the server prefers PDF.
+	fmt.Println(ah.Negotiate([]string{"image/png;qs=0.7", "application/pdf"}, "text/plain"))
+
+	// Client's preference multiplied by server's one: 0.5*1 > 1*0.4.
+	ah = mimeheader.ParseAcceptHeader("image/png;q=0.5, application/pdf")
+	fmt.Println(ah.Negotiate([]string{"image/png", "application/pdf;qs=0.4"}, "text/plain"))
+	// Output:
+	// application/pdf application/pdf true
+	// image/png image/png true
+}
+
 func TestAcceptHeader_Negotiate(t *testing.T) {
 	t.Parallel()
 
@@ -30,7 +44,7 @@ func TestAcceptHeader_Negotiate(t *testing.T) {
 		t.Run(prov.name, func(t *testing.T) {
 			t.Parallel()
 
-			actHeader, actMType, actMatched := prov.ah.Negotiate(prov.ctypes, prov.dtype)
+			actHeader, actMType, actMatched := prov.ah.Negotiate(prov.ctypes, prov.dtype, prov.modes...)
 			if actHeader.String() != prov.expHeader.String() {
 				t.Errorf("Wrong header matched.\nExpected: %v\nActual: %v", prov.expHeader, actHeader)
 			}
@@ -51,6 +65,7 @@ type acceptHeaderNegotiate struct {
 	ah         mimeheader.AcceptHeader
 	ctypes     []string
 	dtype      string
+	modes      []mimeheader.MatchMode
 	expHeader  mimeheader.MimeHeader
 	expMType   string
 	expMatched bool
@@ -87,13 +102,22 @@ func providerAcceptHeaderNegotiate() []acceptHeaderNegotiate {
 		},
 		{
 			name:       "Sorted list of types with the same structure image/png",
-			ah:         mimeheader.ParseAcceptHeader("application/json;q=1.0,*/*;q=1.0; param=wild,image/png;q=1.0;param=test"),
+			ah:         mimeheader.ParseAcceptHeader("application/json;q=1.0,*/*;q=1.0; param=wild,image/png;param=test;q=1.0"),
 			ctypes:     []string{"application/json;param=1", "image/png"},
 			dtype:      "text/plain",
 			expHeader:  mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "image", Subtype: "png"}},
 			expMType:   "image/png",
 			expMatched: true,
 		},
+		{
+			name:       "Accept extensions do not affect precedence",
+			ah:         mimeheader.ParseAcceptHeader("application/json;q=1.0,*/*;q=1.0; param=wild,image/png;q=1.0;param=test"),
+			ctypes:     []string{"application/json;param=1", "image/png"},
+			dtype:      "text/plain",
+			expHeader:  mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "application", Subtype: "json"}},
+			expMType:   "application/json",
+			expMatched: true,
+		},
 		{
 			name:       "Sorted list of types with the same structure */*",
 			ah:         mimeheader.ParseAcceptHeader("application/json;q=1.0,*/*;q=1.0; param=wild,image/png;q=1.0;param=test"),
@@ -112,5 +136,202 @@ func providerAcceptHeaderNegotiate() []acceptHeaderNegotiate {
 			expMType:   "text/javascript",
 			expMatched: false,
 		},
+		{
+			name:       "Most specific range excludes type",
+			ah:         mimeheader.ParseAcceptHeader("text/*;q=0.5, text/html;q=0"),
+			ctypes:     []string{"text/html"},
+			dtype:      "text/javascript",
+			expHeader:  mimeheader.MimeHeader{},
+			expMType:   "text/javascript",
+			expMatched: false,
+		},
+		{
+			name:       "Most specific range excludes one of types",
+			ah:         mimeheader.ParseAcceptHeader("text/*;q=0.5, text/html;q=0"),
+			ctypes:     []string{"text/html", "text/plain"},
+			dtype:      "text/javascript",
+			expHeader:  mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "text", Subtype: "*"}},
+			expMType:   "text/plain",
+			expMatched: true,
+		},
+		{
+			name:       "Wildcard with zero quality",
+			ah:         mimeheader.ParseAcceptHeader("*/*;q=0"),
+			ctypes:     []string{"application/json", "text/plain"},
+			dtype:      "text/javascript",
+			expHeader:  mimeheader.MimeHeader{},
+			expMType:   "text/javascript",
+			expMatched: false,
+		},
+		{
+			name:       "Wildcard with zero quality and exact type",
+			ah:         mimeheader.ParseAcceptHeader("*/*;q=0, application/json;q=0.1"),
+			ctypes:     []string{"text/plain", "application/json"},
+			dtype:      "text/javascript",
+			expHeader:  mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "application", Subtype: "json"}},
+			expMType:   "application/json",
+			expMatched: true,
+		},
+		{
+			name:       "Quality from the most specific range",
+			ah:         mimeheader.ParseAcceptHeader("text/*;q=0.9, text/html;q=0.1, application/json;q=0.5"),
+			ctypes:     []string{"text/html", "application/json"},
+			dtype:      "text/javascript",
+			expHeader:  mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "application", Subtype: "json"}},
+			expMType:   "application/json",
+			expMatched: true,
+		},
+		{
+			name:       "Highest quality wins regardless of types order",
+			ah:         mimeheader.ParseAcceptHeader("application/xml;q=0.3, application/json;q=0.7"),
+			ctypes:     []string{"application/xml", "application/json"},
+			dtype:      "text/javascript",
+			expHeader:  mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "application", Subtype: "json"}},
+			expMType:   "application/json",
+			expMatched: true,
+		},
+		{
+			name:       "Equal quality resolved by types order",
+			ah:         mimeheader.ParseAcceptHeader("application/*"),
+			ctypes:     []string{"application/xml", "application/json"},
+			dtype:      "text/javascript",
+			expHeader:  mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "application", Subtype: "*"}},
+			expMType:   "application/xml",
+			expMatched: true,
+		},
+		{
+			name:       "Params are ignored by default",
+			ah:         mimeheader.ParseAcceptHeader("text/html;level=1, text/plain;q=0.5"),
+			ctypes:     []string{"text/html;level=2", "text/plain"},
+			dtype:      "text/javascript",
+			expHeader:  mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "text", Subtype: "html"}},
+			expMType:   "text/html",
+			expMatched: true,
+		},
+		{
+			name:       "Params subset",
+			ah:         mimeheader.ParseAcceptHeader("text/html;level=1, text/plain;q=0.5"),
+			ctypes:     []string{"text/html;level=2", "text/plain"},
+			dtype:      "text/javascript",
+			modes:      []mimeheader.MatchMode{mimeheader.MatchParamsSubset},
+			expHeader:  mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "text", Subtype: "plain"}},
+			expMType:   "text/plain",
+			expMatched: true,
+		},
+		{
+			name:       "Params subset with the most specific range",
+			ah:         mimeheader.ParseAcceptHeader("text/*;q=0.3, text/html;q=0.7, text/html;level=1, text/html;level=2;q=0.4"),
+			ctypes:     []string{"text/html;level=2", "text/html;level=3"},
+			dtype:      "text/javascript",
+			modes:      []mimeheader.MatchMode{mimeheader.MatchParamsSubset},
+			expHeader:  mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "text", Subtype: "html"}},
+			expMType:   "text/html",
+			expMatched: true,
+		},
+		{
+			name:       "Params exact",
+			ah:         mimeheader.ParseAcceptHeader("application/json;charset=utf-8"),
+			ctypes:     []string{"application/json", "application/json;charset=UTF-8"},
+			dtype:      "text/javascript",
+			modes:      []mimeheader.MatchMode{mimeheader.MatchParamsExact},
+			expHeader:  mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "application", Subtype: "json"}},
+			expMType:   "application/json",
+			expMatched: true,
+		},
+		{
+			name:       "Suffix is not matched by default",
+			ah:         mimeheader.ParseAcceptHeader("application/json"),
+			ctypes:     []string{"application/problem+json"},
+			dtype:      "text/javascript",
+			expHeader:  mimeheader.MimeHeader{},
+			expMType:   "text/javascript",
+			expMatched: false,
+		},
+		{
+			name:       "Suffix",
+			ah:         mimeheader.ParseAcceptHeader("application/xml;q=0.5, application/json"),
+			ctypes:     []string{"application/vnd.acme.order+xml", "application/vnd.acme.order+json"},
+			dtype:      "text/javascript",
+			modes:      []mimeheader.MatchMode{mimeheader.MatchSuffix},
+			expHeader:  mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "application", Subtype: "json"}, Quality: 1},
+			expMType:   "application/vnd.acme.order+json",
+			expMatched: true,
+		},
+		{
+			name:       "Suffix has less precedence than exact subtype",
+			ah:         mimeheader.ParseAcceptHeader("application/json"),
+			ctypes:     []string{"application/problem+json", "application/json"},
+			dtype:      "text/javascript",
+			modes:      []mimeheader.MatchMode{mimeheader.MatchSuffix},
+			expHeader:  mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "application", Subtype: "json"}, Quality: 1},
+			expMType:   "application/json",
+			expMatched: true,
+		},
+		{
+			name:       "Exact range has precedence over suffix range",
+			ah:         mimeheader.ParseAcceptHeader("application/json, application/problem+json;q=0"),
+			ctypes:     []string{"application/problem+json"},
+			dtype:      "text/javascript",
+			modes:      []mimeheader.MatchMode{mimeheader.MatchSuffix},
+			expHeader:  mimeheader.MimeHeader{},
+			expMType:   "text/javascript",
+			expMatched: false,
+		},
+		{
+			name:       "Suffix has precedence over subtype wildcard",
+			ah:         mimeheader.ParseAcceptHeader("application/*, application/json;q=0"),
+			ctypes:     []string{"application/problem+json", "application/xml"},
+			dtype:      "text/javascript",
+			modes:      []mimeheader.MatchMode{mimeheader.MatchSuffix},
+			expHeader:  mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "application", Subtype: "*"}, Quality: 1},
+			expMType:   "application/xml",
+			expMatched: true,
+		},
+		{
+			name:       "Source quality",
+			ah:         mimeheader.ParseAcceptHeader("*/*"),
+			ctypes:     []string{"image/png;qs=0.7", "application/pdf"},
+			dtype:      "text/javascript",
+			expHeader:  mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "*", Subtype: "*"}, Quality: 1},
+			expMType:   "application/pdf",
+			expMatched: true,
+		},
+		{
+			name:       "Zero source quality",
+			ah:         mimeheader.ParseAcceptHeader("image/png"),
+			ctypes:     []string{"image/png;qs=0"},
+			dtype:      "text/javascript",
+			expHeader:  mimeheader.MimeHeader{},
+			expMType:   "text/javascript",
+			expMatched: false,
+		},
+		{
+			name:       "Source quality above one",
+			ah:         mimeheader.ParseAcceptHeader("image/png;q=0.5, application/pdf"),
+			ctypes:     []string{"image/png;qs=5", "application/pdf"},
+			dtype:      "text/javascript",
+			expHeader:  mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "application", Subtype: "pdf"}, Quality: 1},
+			expMType:   "application/pdf",
+			expMatched: true,
+		},
+		{
+			name:       "Invalid source quality",
+			ah:         mimeheader.ParseAcceptHeader("image/*"),
+			ctypes:     []string{"image/png;qs=abc", "image/gif;qs=-1", "image/jpeg;qs=0.1234"},
+			dtype:      "text/javascript",
+			expHeader:  mimeheader.MimeHeader{},
+			expMType:   "text/javascript",
+			expMatched: false,
+		},
+		{
+			name:       "Source quality is not a param for matching",
+			ah:         mimeheader.ParseAcceptHeader("text/html;level=1;q=0.8, text/html;q=0.4"),
+			ctypes:     []string{"text/html;qs=0.9", "text/html;level=1;QS=0.5"},
+			dtype:      "text/javascript",
+			modes:      []mimeheader.MatchMode{mimeheader.MatchParamsExact},
+			expHeader:  mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "text", Subtype: "html", Params: map[string]string{"level": "1"}}, Quality: 0.8},
+			expMType:   "text/html",
+			expMatched: true,
+		},
 	}
 }
@@ -0,0 +1,180 @@
+package mimeheader_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleAcceptHeader_Rank() {
+	ah := mimeheader.ParseAcceptHeader("text/html, application/xhtml+xml, application/xml;q=0.9, */*;q=0.8")
+
+	for _, offer := range ah.Rank([]string{"application/json", "application/xml", "text/html;qs=0.8", "application/xhtml+xml"}) {
+		fmt.Println(offer.Offer, offer.Accept.String(), offer.Quality)
+	}
+	// Output:
+	// application/xhtml+xml application/xhtml+xml 1
+	// application/xml application/xml 0.9
+	// text/html;qs=0.8 text/html 0.8
+	// application/json */* 0.8
+}
+
+func TestAcceptHeader_Rank(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerAcceptHeaderRank() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			act := mimeheader.ParseAcceptHeader(prov.header).Rank(prov.ctypes, prov.modes...)
+			if !reflect.DeepEqual(prov.exp, act) {
+				t.Fatalf("Wrong ranked offers.\nExpected: %+v\nActual: %+v", prov.exp, act)
+			}
+		})
+	}
+}
+
+// TestAcceptHeader_RankNegotiate checks that the first ranked offer is the negotiated one.
+func TestAcceptHeader_RankNegotiate(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerAcceptHeaderNegotiate() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			accept, mtype, matched := prov.ah.Negotiate(prov.ctypes, prov.dtype, prov.modes...)
+			ranked := prov.ah.Rank(prov.ctypes, prov.modes...)
+
+			if matched != (len(ranked) > 0) {
+				t.Fatalf("Unexpected rank result.\nNegotiated: %t\nRanked: %+v", matched, ranked)
+			}
+
+			if matched && (ranked[0].MimeType.String() != mtype || !reflect.DeepEqual(ranked[0].Accept, accept)) {
+				t.Fatalf("The first ranked offer is not negotiated.\nNegotiated: %s %+v\nRanked: %+v", mtype, accept, ranked[0])
+			}
+		})
+	}
+}
+
+type acceptHeaderRank struct {
+	name   string
+	header string
+	ctypes []string
+	modes  []mimeheader.MatchMode
+	exp    []mimeheader.NegotiationResult
+}
+
+func providerAcceptHeaderRank() []acceptHeaderRank {
+	return []acceptHeaderRank{
+		{
+			name:   "Empty header",
+			header: "",
+			ctypes: []string{"text/html"},
+			exp:    []mimeheader.NegotiationResult{},
+		},
+		{
+			name:   "Empty offers",
+			header: "*/*",
+			ctypes: nil,
+			exp:    []mimeheader.NegotiationResult{},
+		},
+		{
+			name:   "Not acceptable and invalid offers are skipped",
+			header: "text/*, text/plain;q=0",
+			ctypes: []string{"text/plain", "text/html;qs=0", "text/", "application/json", "text/csv"},
+			exp: []mimeheader.NegotiationResult{
+				{
+					Offer:    "text/csv",
+					MimeType: mimeheader.MimeType{Type: "text", Subtype: "csv", Params: map[string]string{}},
+					Accept: mimeheader.MimeHeader{
+						MimeType: mimeheader.MimeType{Type: "text", Subtype: "*", Params: map[string]string{}},
+						Quality:  1,
+					},
+					Quality: 1,
+				},
+			},
+		},
+		{
+			name:   "Quality, specificity and order of offers",
+			header: "image/*;q=0.5, image/png;q=0.5, application/pdf",
+			ctypes: []string{"image/jpeg", "image/png", "image/webp", "application/pdf;qs=0.5"},
+			exp: []mimeheader.NegotiationResult{
+				{
+					Offer:    "application/pdf;qs=0.5",
+					MimeType: mimeheader.MimeType{Type: "application", Subtype: "pdf", Params: map[string]string{}},
+					Accept: mimeheader.MimeHeader{
+						MimeType: mimeheader.MimeType{Type: "application", Subtype: "pdf", Params: map[string]string{}},
+						Quality:  1,
+					},
+					Quality: 0.5,
+				},
+				{
+					Offer:    "image/png",
+					MimeType: mimeheader.MimeType{Type: "image", Subtype: "png", Params: map[string]string{}},
+					Accept: mimeheader.MimeHeader{
+						MimeType: mimeheader.MimeType{Type: "image", Subtype: "png", Params: map[string]string{}},
+						Quality:  0.5,
+					},
+					Quality: 0.5,
+				},
+				{
+					Offer:    "image/jpeg",
+					MimeType: mimeheader.MimeType{Type: "image", Subtype: "jpeg", Params: map[string]string{}},
+					Accept: mimeheader.MimeHeader{
+						MimeType: mimeheader.MimeType{Type: "image", Subtype: "*", Params: map[string]string{}},
+						Quality:  0.5,
+					},
+					Quality: 0.5,
+				},
+				{
+					Offer:    "image/webp",
+					MimeType: mimeheader.MimeType{Type: "image", Subtype: "webp", Params: map[string]string{}},
+					Accept: mimeheader.MimeHeader{
+						MimeType: mimeheader.MimeType{Type: "image", Subtype: "*", Params: map[string]string{}},
+						Quality:  0.5,
+					},
+					Quality: 0.5,
+				},
+			},
+		},
+		{
+			name:   "Suffix",
+			header: "application/json, application/*;q=0.1",
+			ctypes: []string{"application/xml", "application/problem+json", "application/json"},
+			modes:  []mimeheader.MatchMode{mimeheader.MatchSuffix},
+			exp: []mimeheader.NegotiationResult{
+				{
+					Offer:    "application/json",
+					MimeType: mimeheader.MimeType{Type: "application", Subtype: "json", Params: map[string]string{}},
+					Accept: mimeheader.MimeHeader{
+						MimeType: mimeheader.MimeType{Type: "application", Subtype: "json", Params: map[string]string{}},
+						Quality:  1,
+					},
+					Quality: 1,
+				},
+				{
+					Offer:    "application/problem+json",
+					MimeType: mimeheader.MimeType{Type: "application", Subtype: "problem+json", Params: map[string]string{}},
+					Accept: mimeheader.MimeHeader{
+						MimeType: mimeheader.MimeType{Type: "application", Subtype: "json", Params: map[string]string{}},
+						Quality:  1,
+					},
+					Quality: 1,
+				},
+				{
+					Offer:    "application/xml",
+					MimeType: mimeheader.MimeType{Type: "application", Subtype: "xml", Params: map[string]string{}},
+					Accept: mimeheader.MimeHeader{
+						MimeType: mimeheader.MimeType{Type: "application", Subtype: "*", Params: map[string]string{}},
+						Quality:  0.1,
+					},
+					Quality: 0.1,
+				},
+			},
+		},
+	}
+}
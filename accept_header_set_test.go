@@ -12,7 +12,7 @@ func ExampleAcceptHeader_Set() {
 	ah := mimeheader.ParseAcceptHeader("image/png")
 	fmt.Println(ah.Match("application/json"))
 
-	ah.Add(mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "application", Subtype: "*"}})
+	ah.Add(mimeheader.MimeHeader{MimeType: mimeheader.MimeType{Type: "application", Subtype: "*"}, Quality: mimeheader.DefaultQuality})
 	fmt.Println(ah.Match("application/json"))
 }
 
@@ -462,7 +462,7 @@ func provideAcceptHeaderSet() []acceptHeaderSet {
 					MimeType: mimeheader.MimeType{
 						Type:    "application",
 						Subtype: "xml",
-						Params:  map[string]string{"q": "1.0", "test": "t"},
+						Params:  map[string]string{"test": "t"},
 					},
 					Quality: 1,
 				},
@@ -488,7 +488,7 @@ func provideAcceptHeaderSet() []acceptHeaderSet {
 					MimeType: mimeheader.MimeType{
 						Type:    "application",
 						Subtype: "xml",
-						Params:  map[string]string{"q": "1.0", "test": "t"},
+						Params:  map[string]string{"test": "t"},
 					},
 					Quality: 1,
 				},
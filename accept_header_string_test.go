@@ -0,0 +1,120 @@
+package mimeheader_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleAcceptHeader_String() {
+	ah := mimeheader.ParseAcceptHeader(`text/*;q=0.30, TEXT/HTML;Level=1, */*;q=0.1;ext="a b", text/html;q=0.7`)
+
+	fmt.Println(ah.String())
+	// Output:
+	// text/html;level=1, text/html;q=0.7, text/*;q=0.3, */*;q=0.1;ext="a b"
+}
+
+func TestAcceptHeader_String(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerAcceptHeaderString() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			if act := prov.ah.String(); act != prov.exp {
+				t.Fatalf("Wrong header.\nExpected: %s\nActual: %s", prov.exp, act)
+			}
+		})
+	}
+}
+
+// TestAcceptHeader_StringRoundTrip checks that a parsed canonical header is equal to the original one.
+func TestAcceptHeader_StringRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	headers := []string{
+		"",
+		"*/*",
+		"text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8",
+		`text/html;foo="a,b;c=d", application/json;q=0.5;title="say \"hi\", \\o/"`,
+		"*/*; q=0.9; s=1, image/*; q=0.9; s=4, application/json; q=0.9; b=3;, text/plain",
+		"application/json;q=1;ext=1, text/plain;q=0",
+	}
+
+	for _, header := range headers {
+		header := header
+		t.Run(header, func(t *testing.T) {
+			t.Parallel()
+
+			exp := mimeheader.ParseAcceptHeader(header)
+			act := mimeheader.ParseAcceptHeader(exp.String())
+
+			if !reflect.DeepEqual(exp, act) {
+				t.Fatalf("AcceptHeaders are not equal.\nExpected: %+v\nActual: %+v\nHeader: %s", exp, act, exp.String())
+			}
+		})
+	}
+}
+
+type acceptHeaderString struct {
+	name string
+	ah   mimeheader.AcceptHeader
+	exp  string
+}
+
+func providerAcceptHeaderString() []acceptHeaderString {
+	return []acceptHeaderString{
+		{
+			name: "Empty",
+			ah:   mimeheader.AcceptHeader{},
+			exp:  "",
+		},
+		{
+			name: "Unsorted plain header",
+			ah: mimeheader.NewAcceptHeaderPlain([]mimeheader.MimeHeader{
+				{MimeType: mimeheader.MimeType{Type: "*", Subtype: "*"}, Quality: 0.1},
+				{MimeType: mimeheader.MimeType{Type: "application", Subtype: "json"}, Quality: 1},
+				{MimeType: mimeheader.MimeType{Type: "", Subtype: "json"}, Quality: 1},
+			}),
+			exp: "application/json, */*;q=0.1",
+		},
+		{
+			name: "Quality format",
+			ah: mimeheader.NewAcceptHeaderPlain([]mimeheader.MimeHeader{
+				{MimeType: mimeheader.MimeType{Type: "a", Subtype: "a"}, Quality: 1.5},
+				{MimeType: mimeheader.MimeType{Type: "b", Subtype: "b"}, Quality: 0.3333},
+				{MimeType: mimeheader.MimeType{Type: "c", Subtype: "c"}, Quality: 0.9999},
+				{MimeType: mimeheader.MimeType{Type: "d", Subtype: "d"}, Quality: 0.25},
+				{MimeType: mimeheader.MimeType{Type: "e", Subtype: "e"}, Quality: -1},
+			}),
+			exp: "a/a;q=1, c/c;q=1, b/b;q=0.333, d/d;q=0.25, e/e;q=0",
+		},
+		{
+			name: "Positive quality below minimal qvalue",
+			ah: mimeheader.NewAcceptHeaderPlain([]mimeheader.MimeHeader{
+				{MimeType: mimeheader.MimeType{Type: "a", Subtype: "a"}, Quality: 0.0015},
+				{MimeType: mimeheader.MimeType{Type: "b", Subtype: "b"}, Quality: 0.0004},
+				{MimeType: mimeheader.MimeType{Type: "c", Subtype: "c"}, Quality: 0.0000001},
+			}),
+			exp: "a/a;q=0.002, b/b;q=0.001, c/c;q=0.001",
+		},
+		{
+			name: "Params order and quoting",
+			ah: mimeheader.NewAcceptHeaderPlain([]mimeheader.MimeHeader{
+				{
+					MimeType: mimeheader.MimeType{
+						Type:    "text",
+						Subtype: "plain",
+						Params:  map[string]string{"b": "2", "A": `x "y"`, "c": ""},
+					},
+					Quality:    1,
+					Extensions: map[string]string{"z": "1", "y": "a,b"},
+				},
+			}),
+			exp: `text/plain;a="x \"y\"";b=2;c="";q=1;y="a,b";z=1`,
+		},
+	}
+}
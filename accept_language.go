@@ -0,0 +1,216 @@
+package mimeheader
+
+import (
+	"sort"
+	"strings"
+)
+
+// LanguageSeparator separates subtags of a language tag, like "zh-Hant-TW".
+const LanguageSeparator = "-"
+
+// Limits of a language range subtag length (RFC 4647 Sec 2.1).
+const (
+	languageSubtagMaxLen = 8
+	languageSingletonLen = 1
+)
+
+// LanguageRange structure for a language range from Accept-Language header (RFC 4647 Sec 2.1).
+type LanguageRange struct {
+	Tag     string
+	Quality float32
+}
+
+// Valid validates language range by basic language range grammar, like "en", "zh-Hant-TW" or "*".
+func (lr LanguageRange) Valid() bool {
+	if lr.Tag == MimeAny {
+		return true
+	}
+
+	for i, subtag := range strings.Split(lr.Tag, LanguageSeparator) {
+		if subtag == "" || len(subtag) > languageSubtagMaxLen {
+			return false
+		}
+
+		for _, c := range subtag {
+			alpha := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+			digit := c >= '0' && c <= '9'
+
+			// The first subtag MUST contain only letters, the rest can contain digits.
+			if !alpha && (i == 0 || !digit) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Match matches language tag by Basic Filtering (RFC 4647 Sec 3.3.1).
+// Range matches a tag if it exactly equals to the tag or exactly equals to a prefix of the tag
+// such that the first character following the prefix is "-". Wildcard "*" matches any tag.
+// Comparison is case-insensitive.
+func (lr LanguageRange) Match(tag string) bool {
+	if lr.Tag == MimeAny {
+		return true
+	}
+
+	if len(tag) < len(lr.Tag) || !strings.EqualFold(lr.Tag, tag[:len(lr.Tag)]) {
+		return false
+	}
+
+	return len(tag) == len(lr.Tag) || strings.HasPrefix(tag[len(lr.Tag):], LanguageSeparator)
+}
+
+// subtags returns number of subtags in the range. Wildcard has zero subtags.
+func (lr LanguageRange) subtags() int {
+	if lr.Tag == MimeAny {
+		return 0
+	}
+
+	return strings.Count(lr.Tag, LanguageSeparator) + 1
+}
+
+// weight returns quality of the range.
+func (lr LanguageRange) weight() float32 {
+	return lr.Quality
+}
+
+// lessSpecific reports whether the range is less specific than the other one.
+// Wildcard is less specific than a language range, then a range with fewer subtags is less specific.
+func (lr LanguageRange) lessSpecific(other LanguageRange) bool {
+	less, done := lessAny(lr.Tag, other.Tag)
+	if done {
+		return less
+	}
+
+	return lr.subtags() < other.subtags()
+}
+
+type AcceptLanguage struct {
+	Ranges []LanguageRange
+}
+
+func NewAcceptLanguagePlain(ranges []LanguageRange) AcceptLanguage {
+	return AcceptLanguage{Ranges: ranges}
+}
+
+func NewAcceptLanguage(ranges []LanguageRange) AcceptLanguage {
+	al := AcceptLanguage{Ranges: ranges}
+	sortRanges(al.Ranges)
+
+	return al
+}
+
+// Add language range to accept language.
+// LanguageRange will be validated and added ONLY if valid.
+// AcceptLanguage will be sorted.
+// For performance reasons better to use Set, instead of Add.
+func (al *AcceptLanguage) Add(lr LanguageRange) {
+	if !lr.Valid() {
+		return
+	}
+
+	al.Ranges = append(al.Ranges, lr)
+
+	sortRanges(al.Ranges)
+}
+
+// Set all valid ranges to AcceptLanguage (override old ones).
+// Sorting will be applied.
+func (al *AcceptLanguage) Set(lrs []LanguageRange) {
+	al.Ranges = validRanges(lrs)
+}
+
+// Filter returns all acceptable tags from supported tags by Basic Filtering (RFC 4647 Sec 3.3.1).
+// Quality of every tag is taken from the most specific matched range, tags with zero quality are not acceptable.
+// Tags are ordered by precedence the same way as AcceptLanguage.Negotiate chooses them,
+// tags matched by the same range keep order of supported tags.
+func (al AcceptLanguage) Filter(tags []string) []string {
+	type match struct {
+		tag  string
+		lrid int
+	}
+
+	matches := make([]match, 0, len(tags))
+
+	for _, tag := range tags {
+		lrid := mostSpecificRange(al.Ranges, tag)
+		if lrid >= 0 && al.Ranges[lrid].Quality > 0 {
+			matches = append(matches, match{tag: tag, lrid: lrid})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return preferredWeighted(al.Ranges, matches[i].lrid, matches[j].lrid)
+	})
+
+	filtered := make([]string, 0, len(matches))
+	for _, m := range matches {
+		filtered = append(filtered, m.tag)
+	}
+
+	return filtered
+}
+
+// Negotiate return appropriate language tag for current accept list from supported tags by Basic Filtering (RFC 4647 Sec 3.3.1).
+// Quality of every tag is taken from the most specific matched range, tags with zero quality are not acceptable.
+// The tag with the highest quality wins, on equal quality the tag matched by a range with higher precedence wins.
+// First parameter returns matched range from accept language.
+// Second parameter returns matched supported tag.
+// Third parameter returns matched supported tag or default tag applied.
+func (al AcceptLanguage) Negotiate(tags []string, dtag string) (accept LanguageRange, tag string, matched bool) {
+	lrid, tag := negotiateRange(al.Ranges, tags)
+	if lrid >= 0 {
+		return al.Ranges[lrid], tag, true
+	}
+
+	return LanguageRange{}, dtag, false
+}
+
+// Lookup return the single most appropriate language tag from supported tags by Lookup scheme (RFC 4647 Sec 3.4).
+// Ranges are processed by precedence, every range is progressively truncated from the end until a supported tag
+// equals to it, like "zh-Hant-TW" -> "zh-Hant" -> "zh". Wildcard and ranges with zero quality are ignored.
+// Parameters have the same meaning as for AcceptLanguage.Negotiate.
+func (al AcceptLanguage) Lookup(tags []string, dtag string) (accept LanguageRange, tag string, matched bool) {
+	for _, lr := range al.Ranges {
+		if lr.Tag == MimeAny || lr.Quality <= 0 {
+			continue
+		}
+
+		for prefix := lr.Tag; prefix != ""; prefix = truncateLanguage(prefix) {
+			for _, stag := range tags {
+				if strings.EqualFold(prefix, stag) {
+					return lr, stag, true
+				}
+			}
+		}
+	}
+
+	return LanguageRange{}, dtag, false
+}
+
+// Match is the same function as AcceptLanguage.Negotiate.
+// It implements simplified interface to match only one tag and return only matched or not information.
+func (al AcceptLanguage) Match(tag string) bool {
+	_, _, matched := al.Negotiate([]string{tag}, "")
+
+	return matched
+}
+
+// truncateLanguage removes the last subtag of the tag.
+// If the new last subtag is a singleton, like "x" in "en-x-twain", it is removed as well.
+func truncateLanguage(tag string) string {
+	idx := strings.LastIndex(tag, LanguageSeparator)
+	if idx < 0 {
+		return ""
+	}
+
+	tag = tag[:idx]
+
+	idx = strings.LastIndex(tag, LanguageSeparator)
+	if idx >= 0 && len(tag)-idx-1 == languageSingletonLen {
+		tag = tag[:idx]
+	}
+
+	return tag
+}
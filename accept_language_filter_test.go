@@ -0,0 +1,63 @@
+package mimeheader_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleAcceptLanguage_Filter() {
+	al := mimeheader.ParseAcceptLanguage("de-CH, en;q=0.5, de;q=0.8")
+
+	fmt.Println(al.Filter([]string{"en-US", "fr", "de", "de-CH-1996", "en"}))
+	// Output:
+	// [de-CH-1996 de en-US en]
+}
+
+func TestAcceptLanguage_Filter(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerAcceptLanguageFilter() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			act := mimeheader.ParseAcceptLanguage(prov.header).Filter(prov.tags)
+			if !reflect.DeepEqual(prov.exp, act) {
+				t.Fatalf("Unexpected filtered tags.\nExpected: %v\nActual: %v", prov.exp, act)
+			}
+		})
+	}
+}
+
+type acceptLanguageFilter struct {
+	name   string
+	header string
+	tags   []string
+	exp    []string
+}
+
+func providerAcceptLanguageFilter() []acceptLanguageFilter {
+	return []acceptLanguageFilter{
+		{
+			name:   "Empty header",
+			header: "",
+			tags:   []string{"en"},
+			exp:    []string{},
+		},
+		{
+			name:   "Wildcard keeps order",
+			header: "*",
+			tags:   []string{"uk", "en", "de"},
+			exp:    []string{"uk", "en", "de"},
+		},
+		{
+			name:   "Excluded tags",
+			header: "*, en;q=0, en-GB;q=0.5",
+			tags:   []string{"en-US", "en-GB", "uk"},
+			exp:    []string{"uk", "en-GB"},
+		},
+	}
+}
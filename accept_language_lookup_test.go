@@ -0,0 +1,121 @@
+package mimeheader_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleAcceptLanguage_Lookup() {
+	al := mimeheader.ParseAcceptLanguage("zh-Hant-TW, en;q=0.5")
+
+	fmt.Println(al.Lookup([]string{"en", "zh-Hant", "zh"}, "uk"))
+	fmt.Println(al.Lookup([]string{"en-US", "zh"}, "uk"))
+	fmt.Println(al.Lookup([]string{"en-US", "de"}, "uk"))
+	// Output:
+	// {zh-Hant-TW 1} zh-Hant true
+	// {zh-Hant-TW 1} zh true
+	// { 0} uk false
+}
+
+func TestAcceptLanguage_Lookup(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerAcceptLanguageLookup() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			al := mimeheader.ParseAcceptLanguage(prov.header)
+
+			actRange, actTag, actMatched := al.Lookup(prov.tags, prov.dtag)
+			if actRange != prov.expRange {
+				t.Errorf("Wrong range matched.\nExpected: %v\nActual: %v", prov.expRange, actRange)
+			}
+
+			if actTag != prov.expTag {
+				t.Errorf("Wrong tag returned.\nExpected: %s\nActual: %s", prov.expTag, actTag)
+			}
+
+			if actMatched != prov.expMatched {
+				t.Errorf("Unepected match result.\nExpected: %t\nActual: %t", prov.expMatched, actMatched)
+			}
+		})
+	}
+}
+
+type acceptLanguageLookup struct {
+	name       string
+	header     string
+	tags       []string
+	dtag       string
+	expRange   mimeheader.LanguageRange
+	expTag     string
+	expMatched bool
+}
+
+func providerAcceptLanguageLookup() []acceptLanguageLookup {
+	return []acceptLanguageLookup{
+		{
+			name:       "Empty header",
+			header:     "",
+			tags:       []string{"en"},
+			dtag:       "uk",
+			expTag:     "uk",
+			expMatched: false,
+		},
+		{
+			name:       "Wildcard is ignored",
+			header:     "*",
+			tags:       []string{"en"},
+			dtag:       "uk",
+			expTag:     "uk",
+			expMatched: false,
+		},
+		{
+			name:       "Exact match",
+			header:     "de-CH",
+			tags:       []string{"de", "de-CH"},
+			dtag:       "uk",
+			expRange:   mimeheader.LanguageRange{Tag: "de-CH", Quality: 1},
+			expTag:     "de-CH",
+			expMatched: true,
+		},
+		{
+			name:       "Singleton is removed on truncation",
+			header:     "zh-Hant-CN-x-private1-private2",
+			tags:       []string{"zh-Hant-CN-x"},
+			dtag:       "uk",
+			expTag:     "uk",
+			expMatched: false,
+		},
+		{
+			name:       "Truncation to the longest supported tag",
+			header:     "zh-Hant-CN-x-private1-private2",
+			tags:       []string{"zh", "zh-Hant-CN"},
+			dtag:       "uk",
+			expRange:   mimeheader.LanguageRange{Tag: "zh-Hant-CN-x-private1-private2", Quality: 1},
+			expTag:     "zh-Hant-CN",
+			expMatched: true,
+		},
+		{
+			name:       "Ranges are processed by precedence",
+			header:     "fr;q=0.5, de-AT",
+			tags:       []string{"fr", "de"},
+			dtag:       "uk",
+			expRange:   mimeheader.LanguageRange{Tag: "de-AT", Quality: 1},
+			expTag:     "de",
+			expMatched: true,
+		},
+		{
+			name:       "Zero quality is ignored",
+			header:     "fr;q=0, de;q=0.1",
+			tags:       []string{"fr", "de"},
+			dtag:       "uk",
+			expRange:   mimeheader.LanguageRange{Tag: "de", Quality: 0.1},
+			expTag:     "de",
+			expMatched: true,
+		},
+	}
+}
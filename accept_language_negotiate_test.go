@@ -0,0 +1,130 @@
+package mimeheader_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleAcceptLanguage_Negotiate() {
+	al := mimeheader.ParseAcceptLanguage("en-US, en;q=0.9, de;q=0.5, *;q=0")
+
+	fmt.Println(al.Negotiate([]string{"de-DE", "en-GB"}, "uk"))
+	fmt.Println(al.Negotiate([]string{"de-AT", "fr"}, "uk"))
+	fmt.Println(al.Negotiate([]string{"fr", "it"}, "uk"))
+	// Output:
+	// {en 0.9} en-GB true
+	// {de 0.5} de-AT true
+	// { 0} uk false
+}
+
+func TestAcceptLanguage_Negotiate(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerAcceptLanguageNegotiate() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			al := mimeheader.ParseAcceptLanguage(prov.header)
+
+			actRange, actTag, actMatched := al.Negotiate(prov.tags, prov.dtag)
+			if actRange != prov.expRange {
+				t.Errorf("Wrong range matched.\nExpected: %v\nActual: %v", prov.expRange, actRange)
+			}
+
+			if actTag != prov.expTag {
+				t.Errorf("Wrong tag returned.\nExpected: %s\nActual: %s", prov.expTag, actTag)
+			}
+
+			if actMatched != prov.expMatched {
+				t.Errorf("Unepected match result.\nExpected: %t\nActual: %t", prov.expMatched, actMatched)
+			}
+		})
+	}
+}
+
+type acceptLanguageNegotiate struct {
+	name       string
+	header     string
+	tags       []string
+	dtag       string
+	expRange   mimeheader.LanguageRange
+	expTag     string
+	expMatched bool
+}
+
+func providerAcceptLanguageNegotiate() []acceptLanguageNegotiate {
+	return []acceptLanguageNegotiate{
+		{
+			name:       "Empty header",
+			header:     "",
+			tags:       []string{"en"},
+			dtag:       "uk",
+			expTag:     "uk",
+			expMatched: false,
+		},
+		{
+			name:       "Empty tags",
+			header:     "*",
+			tags:       []string{},
+			dtag:       "uk",
+			expTag:     "uk",
+			expMatched: false,
+		},
+		{
+			name:       "Prefix match",
+			header:     "zh-Hant",
+			tags:       []string{"zh", "zh-Hans-CN", "zh-Hant-TW"},
+			dtag:       "en",
+			expRange:   mimeheader.LanguageRange{Tag: "zh-Hant", Quality: 1},
+			expTag:     "zh-Hant-TW",
+			expMatched: true,
+		},
+		{
+			name:       "Prefix MUST end on subtag boundary",
+			header:     "en",
+			tags:       []string{"eng"},
+			dtag:       "uk",
+			expTag:     "uk",
+			expMatched: false,
+		},
+		{
+			name:       "Case insensitive",
+			header:     "EN-us",
+			tags:       []string{"en-US"},
+			dtag:       "uk",
+			expRange:   mimeheader.LanguageRange{Tag: "EN-us", Quality: 1},
+			expTag:     "en-US",
+			expMatched: true,
+		},
+		{
+			name:       "Most specific range excludes tag",
+			header:     "en, en-GB;q=0",
+			tags:       []string{"en-GB", "en-US"},
+			dtag:       "uk",
+			expRange:   mimeheader.LanguageRange{Tag: "en", Quality: 1},
+			expTag:     "en-US",
+			expMatched: true,
+		},
+		{
+			name:       "Highest quality wins regardless of tags order",
+			header:     "de;q=0.3, fr;q=0.6",
+			tags:       []string{"de", "fr"},
+			dtag:       "uk",
+			expRange:   mimeheader.LanguageRange{Tag: "fr", Quality: 0.6},
+			expTag:     "fr",
+			expMatched: true,
+		},
+		{
+			name:       "More specific range wins on equal quality",
+			header:     "*, de",
+			tags:       []string{"fr", "de"},
+			dtag:       "uk",
+			expRange:   mimeheader.LanguageRange{Tag: "de", Quality: 1},
+			expTag:     "de",
+			expMatched: true,
+		},
+	}
+}
@@ -39,6 +39,57 @@ func BenchmarkParseAcceptHeaderAndCompareOne(b *testing.B) {
 	benchmarkParseAcceptHeaderAndCompare(b, header, negotiate)
 }
 
+func BenchmarkNegotiatorLong(b *testing.B) {
+	header := "*/*; q=0.9; s=1, image/*; q=0.9; s=4, application/json; q=0.9; b=3;, text/plain"
+	negotiate := []string{"application/json", "application/xml"}
+
+	benchmarkNegotiator(b, header, negotiate)
+}
+
+func BenchmarkNegotiatorThreeWithWights(b *testing.B) {
+	header := "*/*; q=0.9;, image/*; q=0.9, application/json; q=0.9"
+	negotiate := []string{"application/json", "application/xml"}
+
+	benchmarkNegotiator(b, header, negotiate)
+}
+
+func BenchmarkNegotiatorOne(b *testing.B) {
+	header := "*/*"
+	negotiate := []string{"application/json", "application/xml"}
+
+	benchmarkNegotiator(b, header, negotiate)
+}
+
+func BenchmarkNegotiatorBrowser(b *testing.B) {
+	header := "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8"
+	negotiate := []string{"application/json", "text/html", "application/xml"}
+
+	benchmarkNegotiator(b, header, negotiate)
+}
+
+func BenchmarkParseAcceptHeaderAndCompareBrowser(b *testing.B) {
+	header := "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8"
+	negotiate := []string{"application/json", "text/html", "application/xml"}
+
+	benchmarkParseAcceptHeaderAndCompare(b, header, negotiate)
+}
+
+func benchmarkNegotiator(b *testing.B, header string, negotiate []string) {
+	b.Helper()
+
+	negotiator, err := mimeheader.NewNegotiator(negotiate, "")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		negotiator.Negotiate(header)
+	}
+}
+
 func benchmarkParseAcceptHeaderAndCompare(b *testing.B, header string, negotiate []string) {
 	b.Helper()
 
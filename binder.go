@@ -0,0 +1,148 @@
+package mimeheader
+
+import "net/http"
+
+// Error messages of Binder.
+const (
+	UnsupportedMediaTypeErrMsg = "unsupported media type"
+	UnsupportedCharsetErrMsg   = "unsupported charset"
+	DecodeErrMsg               = "error in decoding of a request"
+	BindRangeErrMsg            = "invalid media range of a decoder"
+)
+
+// Decoder decodes a request body to the value.
+type Decoder interface {
+	Decode(r *http.Request, v interface{}) error
+}
+
+// DecoderFunc is an adapter to use ordinary functions as Decoder.
+type DecoderFunc func(r *http.Request, v interface{}) error
+
+// Decode calls f(r, v).
+func (f DecoderFunc) Decode(r *http.Request, v interface{}) error {
+	return f(r, v)
+}
+
+// BinderOptions configures Binder.
+type BinderOptions struct {
+	// Charsets supported by decoders, they are compared with IANA aliases. Request without charset is always supported.
+	// UTF-8 and US-ASCII are supported if the list is empty.
+	// Bodies are not transcoded: built-in decoders, like JSONDecoder, support only UTF-8 and US-ASCII
+	// and reject other charsets, custom decoders MUST transcode bodies of other charsets themselves.
+	Charsets []string
+}
+
+// Binder decodes request bodies by a decoder registered for a media range matched Content-Type of a request.
+// Binder is safe for concurrent use after registration.
+type Binder struct {
+	decoders []binderDecoder
+	charsets []string
+}
+
+type binderDecoder struct {
+	mrange MimeType
+	str    string
+	dec    Decoder
+}
+
+// NewBinder creates Binder without registered decoders.
+func NewBinder(opts BinderOptions) *Binder {
+	charsets := opts.Charsets
+	if len(charsets) == 0 {
+		charsets = []string{CharsetUTF8, CharsetUSASCII}
+	}
+
+	return &Binder{charsets: charsets}
+}
+
+// Register adds the media range with the decoder, like "application/json" or "text/*".
+// Range of a base type matches types with its structured syntax suffix as well, like for MatchSuffix mode.
+// OfferErr is returned for invalid range.
+func (b *Binder) Register(mrange string, dec Decoder) error {
+	mtype, err := ParseMediaType(mrange)
+	if err != nil {
+		return OfferErr{Err: err, Msg: BindRangeErrMsg, Offer: mrange}
+	}
+
+	b.decoders = append(b.decoders, binderDecoder{mrange: mtype, str: mrange, dec: dec})
+
+	return nil
+}
+
+// Types returns registered media ranges in order of registration.
+func (b *Binder) Types() []string {
+	types := make([]string, 0, len(b.decoders))
+	for _, d := range b.decoders {
+		types = append(types, d.str)
+	}
+
+	return types
+}
+
+// Bind decodes body of the request to the value by a decoder registered for Content-Type of the request.
+// Charset param of Content-Type MUST be supported by BinderOptions.Charsets and by the decoder.
+// The most specific matched range is used: exact type, then a base type matched by suffix, then wildcards.
+// Ranges with the same specificity are resolved by order of registration.
+// UnsupportedMediaTypeErr is returned if Content-Type is missed, invalid, has unsupported charset or nothing matched.
+// Errors of decoders are wrapped by DecodeErr.
+func (b *Binder) Bind(r *http.Request, v interface{}) error {
+	ctype := r.Header.Get(HeaderContentType)
+
+	mtype, err := parseContentType(ctype)
+	if err != nil {
+		return b.unsupported(UnsupportedMediaTypeErrMsg, ctype)
+	}
+
+	did := b.mostSpecific(mtype)
+	if did < 0 {
+		return b.unsupported(UnsupportedMediaTypeErrMsg, ctype)
+	}
+
+	if charset, ok := lookupParam(mtype.Params, CharsetParam); ok && !b.supportedCharset(charset, b.decoders[did].dec) {
+		return b.unsupported(UnsupportedCharsetErrMsg, ctype)
+	}
+
+	if err := b.decoders[did].dec.Decode(r, v); err != nil {
+		return DecodeErr{Err: err, Msg: DecodeErrMsg, MimeType: mtype.String()}
+	}
+
+	return nil
+}
+
+// mostSpecific returns index of the most specific decoder matched the type or -1 if nothing matched.
+func (b *Binder) mostSpecific(mtype MimeType) int {
+	did, dsuffix := -1, false
+
+	for id, d := range b.decoders {
+		matched, suffix := d.mrange.match(mtype, MatchSuffix)
+		if !matched {
+			continue
+		}
+
+		if did < 0 || lessSpecificRange(b.decoders[did].mrange.specificity(dsuffix), d.mrange.specificity(suffix)) {
+			did, dsuffix = id, suffix
+		}
+	}
+
+	return did
+}
+
+// supportedCharset reports whether the charset is supported by the Binder and the decoder.
+// Built-in decoders don't transcode bodies, so they support only UTF-8 and US-ASCII.
+func (b *Binder) supportedCharset(charset string, dec Decoder) bool {
+	if _, ok := dec.(utf8Decoder); ok && !equalCharsets(charset, CharsetUTF8) && !equalCharsets(charset, CharsetUSASCII) {
+		return false
+	}
+
+	for _, supported := range b.charsets {
+		if equalCharsets(supported, charset) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (b *Binder) unsupported(msg, ctype string) error {
+	return UnsupportedMediaTypeErr{Msg: msg, MimeType: ctype, Types: b.Types()}
+}
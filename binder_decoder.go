@@ -0,0 +1,185 @@
+package mimeheader
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+)
+
+// Error messages of form decoders.
+const (
+	FormValueErrMsg     = "form decoder supports only *url.Values, *multipart.Form and pointers to structs"
+	FormFieldErrMsg     = "error in decoding of a form field"
+	FormFieldTypeErrMsg = "unsupported type of a form field"
+)
+
+// formTag is a struct field tag with a name of a form field, "-" skips the field.
+const formTag = "form"
+
+// utf8Decoder is a built-in decoder, it doesn't transcode bodies and supports only UTF-8 and US-ASCII charsets.
+type utf8Decoder struct {
+	DecoderFunc
+}
+
+// JSONDecoder decodes UTF-8 request bodies by encoding/json.
+func JSONDecoder() Decoder {
+	return utf8Decoder{func(r *http.Request, v interface{}) error {
+		return json.NewDecoder(r.Body).Decode(v)
+	}}
+}
+
+// XMLDecoder decodes UTF-8 request bodies by encoding/xml.
+func XMLDecoder() Decoder {
+	return utf8Decoder{func(r *http.Request, v interface{}) error {
+		return xml.NewDecoder(r.Body).Decode(v)
+	}}
+}
+
+// FormDecoder decodes UTF-8 "application/x-www-form-urlencoded" request bodies, query params are ignored.
+// The value MUST be *url.Values or a pointer to a struct. Struct fields are matched by "form" tags or names,
+// fields with "-" tag are skipped. Strings, booleans, numbers and slices of them are supported.
+func FormDecoder() Decoder {
+	return utf8Decoder{func(r *http.Request, v interface{}) error {
+		if err := r.ParseForm(); err != nil {
+			return err
+		}
+
+		return decodeForm(r.PostForm, nil, v)
+	}}
+}
+
+// MultipartDecoder decodes UTF-8 "multipart/form-data" request bodies, maxMemory has the same meaning as for
+// http.Request.ParseMultipartForm. The value MUST be *multipart.Form, *url.Values or a pointer to a struct.
+// Struct fields are decoded like by FormDecoder, *multipart.FileHeader and []*multipart.FileHeader fields get files.
+func MultipartDecoder(maxMemory int64) Decoder {
+	return utf8Decoder{func(r *http.Request, v interface{}) error {
+		if err := r.ParseMultipartForm(maxMemory); err != nil {
+			return err
+		}
+
+		if form, ok := v.(*multipart.Form); ok {
+			*form = *r.MultipartForm
+
+			return nil
+		}
+
+		return decodeForm(r.MultipartForm.Value, r.MultipartForm.File, v)
+	}}
+}
+
+// decodeForm decodes form values and files to the value.
+func decodeForm(values url.Values, files map[string][]*multipart.FileHeader, v interface{}) error {
+	if uv, ok := v.(*url.Values); ok {
+		*uv = values
+
+		return nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return FormValueErr{Msg: FormValueErrMsg, Type: fmt.Sprintf("%T", v)}
+	}
+
+	rv = rv.Elem()
+
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+
+		name := field.Tag.Get(formTag)
+		if field.PkgPath != "" || name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		if err := decodeFormField(rv.Field(i), values[name], files[name]); err != nil {
+			return FormFieldErr{Err: err, Msg: FormFieldErrMsg, Field: name}
+		}
+	}
+
+	return nil
+}
+
+// decodeFormField sets values or files to the field, missed values keep the field as is.
+func decodeFormField(fv reflect.Value, values []string, files []*multipart.FileHeader) error {
+	switch fv.Interface().(type) {
+	case *multipart.FileHeader:
+		if len(files) > 0 {
+			fv.Set(reflect.ValueOf(files[0]))
+		}
+
+		return nil
+	case []*multipart.FileHeader:
+		if len(files) > 0 {
+			fv.Set(reflect.ValueOf(files))
+		}
+
+		return nil
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	if fv.Kind() != reflect.Slice {
+		return setFormValue(fv, values[0])
+	}
+
+	slice := reflect.MakeSlice(fv.Type(), len(values), len(values))
+	for i, value := range values {
+		if err := setFormValue(slice.Index(i), value); err != nil {
+			return err
+		}
+	}
+
+	fv.Set(slice)
+
+	return nil
+}
+
+// setFormValue parses the value by kind of the field.
+func setFormValue(fv reflect.Value, value string) error {
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		fv.SetFloat(f)
+	default:
+		return FormValueErr{Msg: FormFieldTypeErrMsg, Type: fv.Type().String()}
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package mimeheader_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+type formValue struct {
+	Name     string   `form:"name"`
+	Tags     []string `form:"tag"`
+	IDs      []uint8  `form:"id"`
+	Score    float32
+	Active   bool                    `form:"active"`
+	Delta    int64                   `form:"delta"`
+	Skipped  string                  `form:"-"`
+	Avatar   *multipart.FileHeader   `form:"avatar"`
+	Files    []*multipart.FileHeader `form:"file"`
+	internal string
+}
+
+func TestFormDecoder(t *testing.T) {
+	t.Parallel()
+
+	body := "name=Ann&tag=a&tag=b&id=1&id=2&Score=1.5&active=true&delta=-3&-=x&internal=x"
+
+	r := httptest.NewRequest(http.MethodPost, "/?name=Query", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var act formValue
+	if err := mimeheader.FormDecoder().Decode(r, &act); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	exp := formValue{Name: "Ann", Tags: []string{"a", "b"}, IDs: []uint8{1, 2}, Score: 1.5, Active: true, Delta: -3}
+	if !reflect.DeepEqual(exp, act) {
+		t.Fatalf("Wrong decoded value.\nExpected: %+v\nActual: %+v", exp, act)
+	}
+}
+
+func TestFormDecoder_errors(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerFormDecoderErrors() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(prov.body))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			err := mimeheader.FormDecoder().Decode(r, prov.value)
+			if reflect.TypeOf(err) != reflect.TypeOf(prov.expErr) {
+				t.Fatalf("Unexpected error.\nExpected: %T\nActual: %v", prov.expErr, err)
+			}
+		})
+	}
+}
+
+type formDecoderErrors struct {
+	name   string
+	body   string
+	value  interface{}
+	expErr error
+}
+
+func providerFormDecoderErrors() []formDecoderErrors {
+	return []formDecoderErrors{
+		{name: "Not a pointer", body: "name=Ann", value: formValue{}, expErr: mimeheader.FormValueErr{}},
+		{name: "Pointer to map", body: "name=Ann", value: &map[string]string{}, expErr: mimeheader.FormValueErr{}},
+		{name: "Unsupported field type", body: "Field=1", value: &struct{ Field complex64 }{}, expErr: mimeheader.FormFieldErr{}},
+		{name: "Overflow", body: "id=256", value: &formValue{}, expErr: mimeheader.FormFieldErr{}},
+		{name: "Invalid bool", body: "active=yes", value: &formValue{}, expErr: mimeheader.FormFieldErr{}},
+		{name: "Invalid float", body: "Score=x", value: &formValue{}, expErr: mimeheader.FormFieldErr{}},
+	}
+}
+
+func TestFormDecoder_values(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequest(http.MethodPost, "/?q=1", strings.NewReader("name=Ann"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var act url.Values
+	if err := mimeheader.FormDecoder().Decode(r, &act); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if exp := (url.Values{"name": {"Ann"}}); !reflect.DeepEqual(exp, act) {
+		t.Fatalf("Wrong decoded value.\nExpected: %v\nActual: %v", exp, act)
+	}
+}
+
+func TestMultipartDecoder(t *testing.T) {
+	t.Parallel()
+
+	var body bytes.Buffer
+
+	mw := multipart.NewWriter(&body)
+	_ = mw.WriteField("name", "Ann")
+
+	for _, file := range []struct{ field, name string }{{"avatar", "a.png"}, {"file", "1.txt"}, {"file", "2.txt"}} {
+		fw, err := mw.CreateFormFile(file.field, file.name)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		_, _ = io.WriteString(fw, file.name)
+	}
+
+	_ = mw.Close()
+
+	newRequest := func() *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body.Bytes()))
+		r.Header.Set("Content-Type", mw.FormDataContentType())
+
+		return r
+	}
+
+	var act formValue
+	if err := mimeheader.MultipartDecoder(1<<20).Decode(newRequest(), &act); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if act.Name != "Ann" || act.Avatar == nil || act.Avatar.Filename != "a.png" || len(act.Files) != 2 || act.Files[1].Filename != "2.txt" {
+		t.Fatalf("Wrong decoded value: %+v", act)
+	}
+
+	var form multipart.Form
+	if err := mimeheader.MultipartDecoder(1<<20).Decode(newRequest(), &form); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(form.Value["name"]) != 1 || len(form.File["file"]) != 2 {
+		t.Fatalf("Wrong decoded form: %+v", form)
+	}
+}
@@ -0,0 +1,248 @@
+package mimeheader_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+type bindUser struct {
+	Name string `json:"name" xml:"name" form:"name"`
+	Age  int    `json:"age" xml:"age" form:"age"`
+}
+
+func newTestBinder(opts mimeheader.BinderOptions) *mimeheader.Binder {
+	b := mimeheader.NewBinder(opts)
+
+	for _, reg := range []struct {
+		mrange string
+		dec    mimeheader.Decoder
+	}{
+		{mrange: "application/json", dec: mimeheader.JSONDecoder()},
+		{mrange: "application/xml", dec: mimeheader.XMLDecoder()},
+		{mrange: "text/xml", dec: mimeheader.XMLDecoder()},
+		{mrange: "application/x-www-form-urlencoded", dec: mimeheader.FormDecoder()},
+		{mrange: "multipart/form-data", dec: mimeheader.MultipartDecoder(1 << 20)},
+	} {
+		if err := b.Register(reg.mrange, reg.dec); err != nil {
+			panic(err)
+		}
+	}
+
+	return b
+}
+
+func ExampleBinder() {
+	b := newTestBinder(mimeheader.BinderOptions{})
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"Ann","age":30}`))
+	r.Header.Set("Content-Type", "application/merge-patch+json; charset=UTF-8")
+
+	var user bindUser
+	fmt.Println(b.Bind(r, &user), user)
+
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("Ann"))
+	r.Header.Set("Content-Type", "text/plain")
+
+	err := b.Bind(r, &user)
+
+	var unsupported mimeheader.UnsupportedMediaTypeErr
+	if errors.As(err, &unsupported) {
+		fmt.Println(unsupported.StatusCode(), unsupported.Types)
+	}
+	// Output:
+	// <nil> {Ann 30}
+	// 415 [application/json application/xml text/xml application/x-www-form-urlencoded multipart/form-data]
+}
+
+func TestBinder_Bind(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerBinderBind() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			b := newTestBinder(prov.opts)
+
+			r := httptest.NewRequest(http.MethodPost, "/?name=Query", strings.NewReader(prov.body))
+			if prov.ctype != "" {
+				r.Header.Set("Content-Type", prov.ctype)
+			}
+
+			var act bindUser
+
+			err := b.Bind(r, &act)
+			if reflect.TypeOf(err) != reflect.TypeOf(prov.expErr) {
+				t.Fatalf("Unexpected error.\nExpected: %T\nActual: %v", prov.expErr, err)
+			}
+
+			if act != prov.exp {
+				t.Fatalf("Wrong decoded value.\nExpected: %+v\nActual: %+v", prov.exp, act)
+			}
+		})
+	}
+}
+
+func TestBinder_mostSpecific(t *testing.T) {
+	t.Parallel()
+
+	var called []string
+
+	b := mimeheader.NewBinder(mimeheader.BinderOptions{})
+	for _, mrange := range []string{"*/*", "application/*", "application/json", "application/problem+json"} {
+		mrange := mrange
+
+		err := b.Register(mrange, mimeheader.DecoderFunc(func(r *http.Request, v interface{}) error {
+			called = append(called, mrange)
+
+			return nil
+		}))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	for _, ctype := range []string{"application/problem+json", "application/merge-patch+json", "application/xml", "text/plain"} {
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		r.Header.Set("Content-Type", ctype)
+
+		if err := b.Bind(r, nil); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	exp := []string{"application/problem+json", "application/json", "application/*", "*/*"}
+	if !reflect.DeepEqual(exp, called) {
+		t.Fatalf("Wrong decoders were called.\nExpected: %v\nActual: %v", exp, called)
+	}
+}
+
+func TestBinder_BindCustomCharset(t *testing.T) {
+	t.Parallel()
+
+	b := mimeheader.NewBinder(mimeheader.BinderOptions{Charsets: []string{"ISO-8859-1"}})
+
+	// The decoder transcodes ISO-8859-1 body to UTF-8, every byte is a code point.
+	err := b.Register("text/plain", mimeheader.DecoderFunc(func(r *http.Request, v interface{}) error {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return err
+		}
+
+		runes := make([]rune, 0, len(body))
+		for _, c := range body {
+			runes = append(runes, rune(c))
+		}
+
+		*v.(*string) = string(runes)
+
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("Jos\xe9"))
+	r.Header.Set("Content-Type", "text/plain; charset=latin1")
+
+	var act string
+	if err := b.Bind(r, &act); err != nil || act != "José" {
+		t.Fatalf("Unexpected result.\nExpected: José\nActual: %q %v", act, err)
+	}
+}
+
+type binderBind struct {
+	name   string
+	opts   mimeheader.BinderOptions
+	ctype  string
+	body   string
+	exp    bindUser
+	expErr error
+}
+
+func providerBinderBind() []binderBind {
+	return []binderBind{
+		{
+			name:   "Missing Content-Type",
+			body:   `{"name":"Ann"}`,
+			expErr: mimeheader.UnsupportedMediaTypeErr{},
+		},
+		{
+			name:   "Wildcard Content-Type",
+			ctype:  "application/*",
+			body:   `{"name":"Ann"}`,
+			expErr: mimeheader.UnsupportedMediaTypeErr{},
+		},
+		{
+			name:   "Unsupported Content-Type",
+			ctype:  "application/yaml",
+			body:   "name: Ann",
+			expErr: mimeheader.UnsupportedMediaTypeErr{},
+		},
+		{
+			name:  "JSON",
+			ctype: "Application/JSON",
+			body:  `{"name":"Ann","age":30}`,
+			exp:   bindUser{Name: "Ann", Age: 30},
+		},
+		{
+			name:   "Invalid JSON",
+			ctype:  "application/json",
+			body:   `{"name":`,
+			expErr: mimeheader.DecodeErr{},
+		},
+		{
+			name:  "XML with charset alias",
+			ctype: "text/xml; charset=utf8",
+			body:  "<user><name>Ann</name><age>30</age></user>",
+			exp:   bindUser{Name: "Ann", Age: 30},
+		},
+		{
+			name:   "Unsupported charset",
+			ctype:  "application/json; charset=UTF-16",
+			body:   `{"name":"Ann"}`,
+			expErr: mimeheader.UnsupportedMediaTypeErr{},
+		},
+		{
+			name:  "Configured charset",
+			opts:  mimeheader.BinderOptions{Charsets: []string{"ISO-8859-1", "US-ASCII"}},
+			ctype: "application/x-www-form-urlencoded; charset=ascii",
+			body:  "name=Ann&age=30",
+			exp:   bindUser{Name: "Ann", Age: 30},
+		},
+		{
+			name:   "Configured charset is not supported by built-in decoder",
+			opts:   mimeheader.BinderOptions{Charsets: []string{"ISO-8859-1"}},
+			ctype:  "application/x-www-form-urlencoded; charset=latin1",
+			body:   "name=Jos%E9",
+			expErr: mimeheader.UnsupportedMediaTypeErr{},
+		},
+		{
+			name:  "Form",
+			ctype: "application/x-www-form-urlencoded",
+			body:  "age=30",
+			exp:   bindUser{Age: 30},
+		},
+		{
+			name:   "Invalid form field",
+			ctype:  "application/x-www-form-urlencoded",
+			body:   "name=Ann&age=thirty",
+			exp:    bindUser{Name: "Ann"},
+			expErr: mimeheader.DecodeErr{},
+		},
+		{
+			name:  "Multipart",
+			ctype: "multipart/form-data; boundary=b",
+			body:  "--b\r\nContent-Disposition: form-data; name=\"name\"\r\n\r\nAnn\r\n--b--\r\n",
+			exp:   bindUser{Name: "Ann"},
+		},
+	}
+}
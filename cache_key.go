@@ -0,0 +1,64 @@
+package mimeheader
+
+// CacheKey reduces Accept header to the negotiated bucket of offered (common) types for caching of responses.
+// The key is the negotiated offered type as it was passed, or the default type if none of types is acceptable,
+// so all Accept headers negotiated to the same type share the key, like browser headers collapse to "text/html".
+// Empty key means that the default type is empty, like for 406 Not Acceptable responses.
+// Arguments have the same meaning as for AcceptHeader.NegotiateResult. Use NegotiationVary to get Vary header value.
+func (ah AcceptHeader) CacheKey(ctypes []string, dtype string, modes ...MatchMode) string {
+	return ah.NegotiateResult(ctypes, dtype, modes...).Offer
+}
+
+// CacheKey parses Accept header and returns the same key as AcceptHeader.CacheKey.
+// It has the same allocation guarantees as Negotiator.Negotiate.
+func (n *Negotiator) CacheKey(header string) string {
+	res, oid := n.negotiate(header)
+	if oid < 0 {
+		return n.dtype
+	}
+
+	return res.Offer
+}
+
+// Vary returns Vary header value for responses negotiated by the Negotiator, like NegotiationVary.
+func (n *Negotiator) Vary() string {
+	acceptable, offer := 0, ""
+
+	for _, o := range n.offers {
+		if o.qs > 0 {
+			acceptable, offer = acceptable+1, o.offer
+		}
+	}
+
+	return negotiationVary(acceptable, offer, n.dtype)
+}
+
+// NegotiationVary returns Vary header value for responses negotiated from offered (common) types and the default type.
+// It's Accept, if the negotiated type depends on Accept header, and empty otherwise:
+// there are no acceptable offered types or the only one is the same as the default type.
+// Invalid types and types with zero source quality are never negotiated.
+func NegotiationVary(ctypes []string, dtype string) string {
+	acceptable, offer := 0, ""
+
+	for _, ctype := range ctypes {
+		mtype, err := ParseMediaType(ctype)
+		if err != nil {
+			continue
+		}
+
+		if qs, err := cutSourceQuality(mtype); err == nil && qs > 0 {
+			acceptable, offer = acceptable+1, ctype
+		}
+	}
+
+	return negotiationVary(acceptable, offer, dtype)
+}
+
+// negotiationVary returns Vary header value by a number of acceptable offered types and the last of them.
+func negotiationVary(acceptable int, offer, dtype string) string {
+	if acceptable == 0 || acceptable == 1 && offer == dtype {
+		return ""
+	}
+
+	return HeaderAccept
+}
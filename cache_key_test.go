@@ -0,0 +1,164 @@
+package mimeheader_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleAcceptHeader_CacheKey() {
+	offers := []string{"text/html", "application/json"}
+
+	for _, header := range []string{
+		"text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8",
+		"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+		"*/*",
+		"application/json",
+		"image/png",
+	} {
+		fmt.Printf("%q\n", mimeheader.ParseAcceptHeader(header).CacheKey(offers, ""))
+	}
+
+	fmt.Println(mimeheader.NegotiationVary(offers, ""))
+	// Output:
+	// "text/html"
+	// "text/html"
+	// "text/html"
+	// "application/json"
+	// ""
+	// Accept
+}
+
+func TestCacheKey(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerCacheKey() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			key := mimeheader.ParseAcceptHeader(prov.header).CacheKey(prov.offers, prov.dtype, prov.modes...)
+			if key != prov.exp {
+				t.Fatalf("Wrong key.\nExpected: %q\nActual: %q", prov.exp, key)
+			}
+
+			n, err := mimeheader.NewNegotiator(prov.offers, prov.dtype, prov.modes...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if key := n.CacheKey(prov.header); key != prov.exp {
+				t.Fatalf("Wrong key of Negotiator.\nExpected: %q\nActual: %q", prov.exp, key)
+			}
+		})
+	}
+}
+
+func TestNegotiationVary(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerNegotiationVary() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			if vary := mimeheader.NegotiationVary(prov.offers, prov.dtype); vary != prov.exp {
+				t.Fatalf("Wrong Vary.\nExpected: %q\nActual: %q", prov.exp, vary)
+			}
+
+			n, err := mimeheader.NewNegotiator(prov.offers, prov.dtype)
+			if err != nil {
+				return
+			}
+
+			if vary := n.Vary(); vary != prov.exp {
+				t.Fatalf("Wrong Vary of Negotiator.\nExpected: %q\nActual: %q", prov.exp, vary)
+			}
+		})
+	}
+}
+
+type cacheKey struct {
+	name   string
+	header string
+	offers []string
+	dtype  string
+	modes  []mimeheader.MatchMode
+	exp    string
+}
+
+func providerCacheKey() []cacheKey {
+	return []cacheKey{
+		{
+			name:   "Browser",
+			header: "text/html,application/xhtml+xml,application/xml;q=0.9,image/webp,*/*;q=0.8",
+			offers: []string{"application/json", "text/html"},
+			exp:    "text/html",
+		},
+		{
+			name:   "Offer with params",
+			header: "application/json;q=0.5, text/*",
+			offers: []string{"application/json", "text/plain;charset=utf-8;qs=0.9"},
+			exp:    "text/plain;charset=utf-8;qs=0.9",
+		},
+		{
+			name:   "Default type",
+			header: "image/png",
+			offers: []string{"application/json", "text/html"},
+			dtype:  "application/json",
+			exp:    "application/json",
+		},
+		{
+			name:   "Not acceptable",
+			header: "image/png",
+			offers: []string{"application/json", "text/html"},
+			exp:    "",
+		},
+		{
+			name:   "Suffix mode",
+			header: "application/json",
+			offers: []string{"text/html", "application/problem+json"},
+			modes:  []mimeheader.MatchMode{mimeheader.MatchSuffix},
+			exp:    "application/problem+json",
+		},
+	}
+}
+
+func providerNegotiationVary() []cacheKey {
+	return []cacheKey{
+		{
+			name:   "Several offers",
+			offers: []string{"application/json", "text/html"},
+			exp:    "Accept",
+		},
+		{
+			name:   "Single offer is default",
+			offers: []string{"application/json"},
+			dtype:  "application/json",
+			exp:    "",
+		},
+		{
+			name:   "Single offer without default",
+			offers: []string{"application/json"},
+			exp:    "Accept",
+		},
+		{
+			name:   "Not acceptable offer is skipped",
+			offers: []string{"application/json", "text/html;qs=0"},
+			dtype:  "application/json",
+			exp:    "",
+		},
+		{
+			name:   "Invalid offer is skipped",
+			offers: []string{"json", "application/json"},
+			dtype:  "application/json",
+			exp:    "",
+		},
+		{
+			name:  "No offers",
+			dtype: "application/json",
+			exp:   "",
+		},
+	}
+}
@@ -0,0 +1,88 @@
+package mimeheader
+
+import "strings"
+
+// CharsetParam is a media type parameter with charset, like "text/html; charset=utf-8".
+const CharsetParam = "charset"
+
+// Preferred MIME names of commonly used charsets from IANA Character Sets registry.
+const (
+	CharsetUTF8        = "UTF-8"
+	CharsetUTF16       = "UTF-16"
+	CharsetUTF16BE     = "UTF-16BE"
+	CharsetUTF16LE     = "UTF-16LE"
+	CharsetUSASCII     = "US-ASCII"
+	CharsetISO88591    = "ISO-8859-1"
+	CharsetISO88592    = "ISO-8859-2"
+	CharsetISO88595    = "ISO-8859-5"
+	CharsetISO885915   = "ISO-8859-15"
+	CharsetWindows1250 = "windows-1250"
+	CharsetWindows1251 = "windows-1251"
+	CharsetWindows1252 = "windows-1252"
+	CharsetKOI8R       = "KOI8-R"
+	CharsetShiftJIS    = "Shift_JIS"
+	CharsetEUCJP       = "EUC-JP"
+	CharsetISO2022JP   = "ISO-2022-JP"
+	CharsetEUCKR       = "EUC-KR"
+	CharsetGB2312      = "GB2312"
+	CharsetGBK         = "GBK"
+	CharsetGB18030     = "GB18030"
+	CharsetBig5        = "Big5"
+)
+
+// CanonicalCharset returns preferred MIME name of the charset by its IANA alias, like "latin1" -> "ISO-8859-1".
+// Comparison is case-insensitive. Unknown charset is returned as is.
+func CanonicalCharset(charset string) string {
+	switch strings.ToLower(charset) {
+	case "utf-8", "utf8", "unicode-1-1-utf-8", "csutf8":
+		return CharsetUTF8
+	case "utf-16", "csutf16":
+		return CharsetUTF16
+	case "utf-16be", "csutf16be":
+		return CharsetUTF16BE
+	case "utf-16le", "csutf16le":
+		return CharsetUTF16LE
+	case "us-ascii", "ascii", "iso-ir-6", "ansi_x3.4-1968", "ansi_x3.4-1986", "iso_646.irv:1991", "iso646-us", "us", "ibm367", "cp367",
+		"csascii":
+		return CharsetUSASCII
+	case "iso-8859-1", "iso_8859-1:1987", "iso_8859-1", "iso-ir-100", "latin1", "l1", "ibm819", "cp819", "csisolatin1":
+		return CharsetISO88591
+	case "iso-8859-2", "iso_8859-2:1987", "iso_8859-2", "iso-ir-101", "latin2", "l2", "csisolatin2":
+		return CharsetISO88592
+	case "iso-8859-5", "iso_8859-5:1988", "iso_8859-5", "iso-ir-144", "cyrillic", "csisolatincyrillic":
+		return CharsetISO88595
+	case "iso-8859-15", "iso_8859-15", "latin-9", "csiso885915":
+		return CharsetISO885915
+	case "windows-1250", "cswindows1250":
+		return CharsetWindows1250
+	case "windows-1251", "cswindows1251":
+		return CharsetWindows1251
+	case "windows-1252", "cswindows1252":
+		return CharsetWindows1252
+	case "koi8-r", "cskoi8r":
+		return CharsetKOI8R
+	case "shift_jis", "ms_kanji", "csshiftjis":
+		return CharsetShiftJIS
+	case "euc-jp", "extended_unix_code_packed_format_for_japanese", "cseucpkdfmtjapanese":
+		return CharsetEUCJP
+	case "iso-2022-jp", "csiso2022jp":
+		return CharsetISO2022JP
+	case "euc-kr", "cseuckr":
+		return CharsetEUCKR
+	case "gb2312", "csgb2312":
+		return CharsetGB2312
+	case "gbk", "cp936", "ms936", "windows-936", "csgbk":
+		return CharsetGBK
+	case "gb18030", "csgb18030":
+		return CharsetGB18030
+	case "big5", "csbig5":
+		return CharsetBig5
+	default:
+		return charset
+	}
+}
+
+// equalCharsets compares charsets case-insensitively with resolved aliases.
+func equalCharsets(a, b string) bool {
+	return strings.EqualFold(CanonicalCharset(a), CanonicalCharset(b))
+}
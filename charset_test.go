@@ -0,0 +1,55 @@
+package mimeheader_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleCanonicalCharset() {
+	fmt.Println(mimeheader.CanonicalCharset("latin1"))
+	fmt.Println(mimeheader.CanonicalCharset("utf8"))
+	fmt.Println(mimeheader.CanonicalCharset("x-unknown"))
+	// Output:
+	// ISO-8859-1
+	// UTF-8
+	// x-unknown
+}
+
+func TestCanonicalCharset(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerCanonicalCharset() {
+		prov := prov
+		t.Run(prov.charset, func(t *testing.T) {
+			t.Parallel()
+
+			act := mimeheader.CanonicalCharset(prov.charset)
+			if act != prov.exp {
+				t.Fatalf("Unexpected canonical charset.\nExpected: %s\nActual: %s", prov.exp, act)
+			}
+		})
+	}
+}
+
+type canonicalCharset struct {
+	charset string
+	exp     string
+}
+
+func providerCanonicalCharset() []canonicalCharset {
+	return []canonicalCharset{
+		{charset: "", exp: ""},
+		{charset: "UTF-8", exp: "UTF-8"},
+		{charset: "utf-8", exp: "UTF-8"},
+		{charset: "ISO_8859-1:1987", exp: "ISO-8859-1"},
+		{charset: "CP819", exp: "ISO-8859-1"},
+		{charset: "ascii", exp: "US-ASCII"},
+		{charset: "latin-9", exp: "ISO-8859-15"},
+		{charset: "CP1252", exp: "CP1252"},
+		{charset: "csWindows1252", exp: "windows-1252"},
+		{charset: "MS_Kanji", exp: "Shift_JIS"},
+		{charset: "cp936", exp: "GBK"},
+	}
+}
@@ -1,5 +1,11 @@
 package mimeheader
 
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
 type MimeParseErr struct {
 	Err error
 	Msg string
@@ -32,3 +38,196 @@ type MimeTypeWildcardErr struct {
 func (e MimeTypeWildcardErr) Error() string {
 	return e.Msg
 }
+
+type SourceQualityErr struct {
+	Msg   string
+	Value string
+}
+
+func (e SourceQualityErr) Error() string {
+	return e.Msg + " " + strconv.Quote(e.Value)
+}
+
+type OfferErr struct {
+	Err   error
+	Msg   string
+	Offer string
+}
+
+func (e OfferErr) Error() string {
+	msg := e.Msg + " " + strconv.Quote(e.Offer)
+	if e.Err == nil {
+		return msg
+	}
+
+	return msg + ": " + e.Err.Error()
+}
+
+func (e OfferErr) Unwrap() error {
+	return e.Err
+}
+
+type NotAcceptableErr struct {
+	Msg string
+	// Types are offered mime types.
+	Types []string
+}
+
+func (e NotAcceptableErr) Error() string {
+	return e.Msg + ": " + strings.Join(e.Types, ListSeparator+" ")
+}
+
+type EncodeErr struct {
+	Err      error
+	Msg      string
+	MimeType string
+}
+
+func (e EncodeErr) Error() string {
+	if e.Err == nil {
+		return e.Msg + " " + strconv.Quote(e.MimeType)
+	}
+
+	return e.Msg + " " + strconv.Quote(e.MimeType) + ": " + e.Err.Error()
+}
+
+func (e EncodeErr) Unwrap() error {
+	return e.Err
+}
+
+type CSVValueErr struct {
+	Msg  string
+	Type string
+}
+
+func (e CSVValueErr) Error() string {
+	return e.Msg + ", got " + e.Type
+}
+
+type UnsupportedMediaTypeErr struct {
+	Msg string
+	// MimeType is Content-Type of a request as is.
+	MimeType string
+	// Types are supported media ranges.
+	Types []string
+}
+
+func (e UnsupportedMediaTypeErr) Error() string {
+	return e.Msg + " " + strconv.Quote(e.MimeType) + ", supported: " + strings.Join(e.Types, ListSeparator+" ")
+}
+
+// StatusCode returns HTTP status code of the error: 415 Unsupported Media Type.
+func (e UnsupportedMediaTypeErr) StatusCode() int {
+	return http.StatusUnsupportedMediaType
+}
+
+type DecodeErr struct {
+	Err      error
+	Msg      string
+	MimeType string
+}
+
+func (e DecodeErr) Error() string {
+	return e.Msg + " " + strconv.Quote(e.MimeType) + ": " + e.Err.Error()
+}
+
+func (e DecodeErr) Unwrap() error {
+	return e.Err
+}
+
+type UnacceptableContentTypeErr struct {
+	Err error
+	Msg string
+	// MimeType is Content-Type of a response as is.
+	MimeType string
+	// Accept is Accept header of a request.
+	Accept string
+	// StatusCode is HTTP status code of a response.
+	StatusCode int
+}
+
+func (e UnacceptableContentTypeErr) Error() string {
+	msg := e.Msg + " " + strconv.Quote(e.MimeType) + ", accepted: " + strconv.Quote(e.Accept)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+
+	return msg
+}
+
+func (e UnacceptableContentTypeErr) Unwrap() error {
+	return e.Err
+}
+
+type FormValueErr struct {
+	Msg  string
+	Type string
+}
+
+func (e FormValueErr) Error() string {
+	return e.Msg + ", got " + e.Type
+}
+
+type FormFieldErr struct {
+	Err   error
+	Msg   string
+	Field string
+}
+
+func (e FormFieldErr) Error() string {
+	return e.Msg + " " + strconv.Quote(e.Field) + ": " + e.Err.Error()
+}
+
+func (e FormFieldErr) Unwrap() error {
+	return e.Err
+}
+
+// AcceptDiagnosticReason describes a violation of Accept header grammar.
+type AcceptDiagnosticReason int
+
+const (
+	AcceptBadToken AcceptDiagnosticReason = iota + 1
+	AcceptBadQuality
+	AcceptDuplicateParam
+	AcceptWildcard
+)
+
+func (r AcceptDiagnosticReason) String() string {
+	switch r {
+	case AcceptBadToken:
+		return AcceptBadTokenMsg
+	case AcceptBadQuality:
+		return AcceptBadQualityMsg
+	case AcceptDuplicateParam:
+		return AcceptDuplicateParamMsg
+	case AcceptWildcard:
+		return AcceptWildcardMsg
+	default:
+		return "unknown reason " + strconv.Itoa(int(r))
+	}
+}
+
+// AcceptDiagnostic describes a media range rejected by ParseAcceptHeaderStrict.
+type AcceptDiagnostic struct {
+	// Index of the range in the header, empty list elements are not counted.
+	Index int
+	// Offset in bytes of the invalid part in the header.
+	Offset int
+	Reason AcceptDiagnosticReason
+	// Range is the rejected media range as is.
+	Range string
+}
+
+func (e AcceptDiagnostic) Error() string {
+	var b strings.Builder
+
+	b.WriteString(e.Reason.String())
+	b.WriteString(" #")
+	b.WriteString(strconv.Itoa(e.Index))
+	b.WriteString(" at offset ")
+	b.WriteString(strconv.Itoa(e.Offset))
+	b.WriteString(": ")
+	b.WriteString(strconv.Quote(e.Range))
+
+	return b.String()
+}
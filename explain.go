@@ -0,0 +1,219 @@
+package mimeheader
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ExplainReason describes why an offered type was chosen or rejected by AcceptHeader.Explain.
+type ExplainReason int
+
+const (
+	// ExplainChosen is the reason of the negotiated type.
+	ExplainChosen ExplainReason = iota + 1
+	// ExplainInvalidOffer is the reason of an offered type which can't be parsed.
+	ExplainInvalidOffer
+	// ExplainNoMatch is the reason of an offered type without matched ranges.
+	ExplainNoMatch
+	// ExplainNotAcceptable is the reason of an offered type with zero effective quality.
+	ExplainNotAcceptable
+	// ExplainLowerQuality is the reason of an offered type with lower effective quality than the negotiated one.
+	ExplainLowerQuality
+	// ExplainLessSpecific is the reason of an offered type matched by less specific range than the negotiated one.
+	ExplainLessSpecific
+	// ExplainLaterRange is the reason of an offered type matched by a range placed after the range of the negotiated type.
+	ExplainLaterRange
+	// ExplainLaterOffer is the reason of an offered type with the same precedence placed after the negotiated type.
+	ExplainLaterOffer
+)
+
+// Messages of explain reasons.
+const (
+	ExplainChosenMsg        = "chosen"
+	ExplainInvalidOfferMsg  = "invalid offer"
+	ExplainNoMatchMsg       = "no matched range"
+	ExplainNotAcceptableMsg = "not acceptable"
+	ExplainLowerQualityMsg  = "lower quality"
+	ExplainLessSpecificMsg  = "less specific range"
+	ExplainLaterRangeMsg    = "later range"
+	ExplainLaterOfferMsg    = "later offer"
+)
+
+func (r ExplainReason) String() string {
+	switch r {
+	case ExplainChosen:
+		return ExplainChosenMsg
+	case ExplainInvalidOffer:
+		return ExplainInvalidOfferMsg
+	case ExplainNoMatch:
+		return ExplainNoMatchMsg
+	case ExplainNotAcceptable:
+		return ExplainNotAcceptableMsg
+	case ExplainLowerQuality:
+		return ExplainLowerQualityMsg
+	case ExplainLessSpecific:
+		return ExplainLessSpecificMsg
+	case ExplainLaterRange:
+		return ExplainLaterRangeMsg
+	case ExplainLaterOffer:
+		return ExplainLaterOfferMsg
+	default:
+		return "unknown reason " + strconv.Itoa(int(r))
+	}
+}
+
+// OfferTrace describes how an offered type was processed by AcceptHeader.Explain.
+type OfferTrace struct {
+	// Offer is the offered type as it was passed to AcceptHeader.Explain.
+	Offer string
+	// Err is a parsing error of the offered type with ExplainInvalidOffer reason.
+	Err error
+	// Matched contains all ranges matched the offered type in order of precedence.
+	Matched []MimeHeader
+	// Accept is the most specific matched range, which quality is used.
+	Accept MimeHeader
+	// Suffix reports whether Accept matched the offered type by the structured syntax suffix.
+	Suffix bool
+	// SourceQuality is a server-side source quality of the offered type from "qs" param.
+	SourceQuality float32
+	// Quality is an effective quality of the offered type: quality of Accept multiplied by SourceQuality.
+	Quality float32
+	// Reason describes why the offered type was chosen or rejected.
+	// Acceptable types are compared with the negotiated one, the reason is the first rule decided the comparison.
+	Reason ExplainReason
+}
+
+func (ot OfferTrace) String() string {
+	var b strings.Builder
+
+	b.WriteString(strconv.Quote(ot.Offer) + ": " + ot.Reason.String())
+
+	if ot.Err != nil {
+		b.WriteString(": " + ot.Err.Error())
+
+		return b.String()
+	}
+
+	if len(ot.Matched) == 0 {
+		return b.String()
+	}
+
+	b.WriteString("; range " + strconv.Quote(ot.Accept.StringWithParams()))
+
+	if ot.Suffix {
+		b.WriteString(" by suffix")
+	}
+
+	b.WriteString(" q=" + formatQValue(ot.Accept.Quality))
+	b.WriteString(", qs=" + formatQValue(ot.SourceQuality))
+	b.WriteString(", quality=" + formatQValue(ot.Quality))
+	b.WriteString("; matched")
+
+	for i, mh := range ot.Matched {
+		if i > 0 {
+			b.WriteString(ListSeparator)
+		}
+
+		b.WriteString(" " + strconv.Quote(mh.StringWithParams()))
+	}
+
+	return b.String()
+}
+
+// Explanation describes how AcceptHeader.Explain negotiated the result.
+type Explanation struct {
+	// Result is the same result as AcceptHeader.NegotiateResult returns.
+	Result NegotiationResult
+	// Offers contains traces of all offered types in order they were passed.
+	Offers []OfferTrace
+}
+
+// String returns a human readable trace, one line for the result and one line for every offered type.
+func (e Explanation) String() string {
+	var b strings.Builder
+
+	if e.Result.Default {
+		b.WriteString("default " + strconv.Quote(e.Result.Offer))
+	} else {
+		b.WriteString("negotiated " + strconv.Quote(e.Result.Offer))
+	}
+
+	for _, ot := range e.Offers {
+		b.WriteString("\n" + ot.String())
+	}
+
+	return b.String()
+}
+
+// Explain negotiates the type the same way as AcceptHeader.NegotiateResult and describes every decision.
+// It's slower than AcceptHeader.NegotiateResult and intended for debugging.
+func (ah AcceptHeader) Explain(ctypes []string, dtype string, modes ...MatchMode) Explanation {
+	mode := mergeModes(modes)
+	traces := make([]OfferTrace, 0, len(ctypes))
+
+	res, win := ah.negotiate(ctypes, mode)
+	if win < 0 {
+		res = defaultResult(dtype)
+	}
+
+	var winMatch offerMatch
+	if win >= 0 {
+		// The negotiated type is valid, it was matched by AcceptHeader.negotiate.
+		winMatch, _ = ah.matchOffer(ctypes[win], mode)
+	}
+
+	for oid, ctype := range ctypes {
+		ot := OfferTrace{Offer: ctype}
+
+		om, err := ah.matchOffer(ctype, mode)
+		if err != nil {
+			ot.Err, ot.Reason = err, ExplainInvalidOffer
+			traces = append(traces, ot)
+
+			continue
+		}
+
+		ot.SourceQuality = om.qs
+
+		for _, header := range ah.MHeaders {
+			if matched, _ := header.match(om.mtype, mode); matched {
+				ot.Matched = append(ot.Matched, header)
+			}
+		}
+
+		if om.hid < 0 {
+			ot.Reason = ExplainNoMatch
+			traces = append(traces, ot)
+
+			continue
+		}
+
+		ot.Accept, ot.Suffix, ot.Quality = ah.MHeaders[om.hid], om.suffix, om.quality
+
+		if om.acceptable() {
+			ot.Reason = ah.explainPreference(winMatch.rangeMatch, om.rangeMatch, oid == win)
+		} else {
+			ot.Reason = ExplainNotAcceptable
+		}
+
+		traces = append(traces, ot)
+	}
+
+	return Explanation{Result: res, Offers: traces}
+}
+
+// explainPreference returns the first rule of AcceptHeader.preferred which gives precedence to match win over match m.
+func (ah AcceptHeader) explainPreference(win, m rangeMatch, chosen bool) ExplainReason {
+	switch {
+	case chosen:
+		return ExplainChosen
+	case win.quality != m.quality:
+		return ExplainLowerQuality
+	case ah.lessSpecificMatch(m, win):
+		return ExplainLessSpecific
+	case win.hid != m.hid:
+		return ExplainLaterRange
+	default:
+		return ExplainLaterOffer
+	}
+}
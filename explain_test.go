@@ -0,0 +1,145 @@
+package mimeheader_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleAcceptHeader_Explain() {
+	ah := mimeheader.ParseAcceptHeader("text/html, application/xhtml+xml, application/xml;q=0.9, */*;q=0.8")
+
+	fmt.Println(ah.Explain([]string{"application/json", "application/xml", "text/", "text/html;qs=0.8"}, "text/plain"))
+	// Output:
+	// negotiated "application/xml"
+	// "application/json": lower quality; range "*/*" q=0.8, qs=1, quality=0.8; matched "*/*"
+	// "application/xml": chosen; range "application/xml" q=0.9, qs=1, quality=0.9; matched "application/xml", "*/*"
+	// "text/": invalid offer: error in a parse media type: mime: expected token after slash
+	// "text/html;qs=0.8": lower quality; range "text/html" q=1, qs=0.8, quality=0.8; matched "text/html", "*/*"
+}
+
+func TestAcceptHeader_Explain(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerAcceptHeaderExplain() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			exp := mimeheader.ParseAcceptHeader(prov.header).Explain(prov.ctypes, prov.dtype, prov.modes...)
+
+			reasons := make([]mimeheader.ExplainReason, 0, len(exp.Offers))
+			for _, ot := range exp.Offers {
+				reasons = append(reasons, ot.Reason)
+			}
+
+			if !reflect.DeepEqual(prov.expReasons, reasons) {
+				t.Fatalf("Wrong reasons.\nExpected: %v\nActual: %v\nExplanation:\n%s", prov.expReasons, reasons, exp)
+			}
+		})
+	}
+}
+
+// TestAcceptHeader_ExplainResult checks that Explain negotiates the same result as NegotiateResult.
+func TestAcceptHeader_ExplainResult(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerAcceptHeaderNegotiate() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			exp := prov.ah.NegotiateResult(prov.ctypes, prov.dtype, prov.modes...)
+			act := prov.ah.Explain(prov.ctypes, prov.dtype, prov.modes...)
+
+			if !reflect.DeepEqual(exp, act.Result) {
+				t.Fatalf("Wrong result.\nExpected: %+v\nActual: %+v", exp, act.Result)
+			}
+
+			if len(act.Offers) != len(prov.ctypes) {
+				t.Fatalf("Wrong number of traces.\nExpected: %d\nActual: %d", len(prov.ctypes), len(act.Offers))
+			}
+		})
+	}
+}
+
+type acceptHeaderExplain struct {
+	name       string
+	header     string
+	ctypes     []string
+	dtype      string
+	modes      []mimeheader.MatchMode
+	expReasons []mimeheader.ExplainReason
+}
+
+func providerAcceptHeaderExplain() []acceptHeaderExplain {
+	return []acceptHeaderExplain{
+		{
+			name:       "Empty",
+			header:     "*/*",
+			ctypes:     nil,
+			dtype:      "text/plain",
+			expReasons: []mimeheader.ExplainReason{},
+		},
+		{
+			name:   "Skipped offers",
+			header: "text/*, text/plain;q=0",
+			ctypes: []string{"*/plain", "text/csv;qs=2", "image/png", "text/plain", "text/html;qs=0"},
+			dtype:  "text/plain",
+			expReasons: []mimeheader.ExplainReason{
+				mimeheader.ExplainInvalidOffer,
+				mimeheader.ExplainInvalidOffer,
+				mimeheader.ExplainNoMatch,
+				mimeheader.ExplainNotAcceptable,
+				mimeheader.ExplainNotAcceptable,
+			},
+		},
+		{
+			name:   "Tie-breaks",
+			header: "text/html;q=0.5, text/*;q=0.5, application/json;q=0.5, image/png;q=0.4",
+			ctypes: []string{"image/png", "text/plain", "application/json", "text/html", "text/html;level=2"},
+			dtype:  "text/plain",
+			expReasons: []mimeheader.ExplainReason{
+				mimeheader.ExplainLowerQuality,
+				mimeheader.ExplainLessSpecific,
+				mimeheader.ExplainLaterRange,
+				mimeheader.ExplainChosen,
+				mimeheader.ExplainLaterOffer,
+			},
+		},
+		{
+			name:   "Params subset",
+			header: "text/html;level=1;q=0.5, text/html;q=0.5",
+			ctypes: []string{"text/html", "text/html;level=1"},
+			dtype:  "text/plain",
+			modes:  []mimeheader.MatchMode{mimeheader.MatchParamsSubset},
+			expReasons: []mimeheader.ExplainReason{
+				mimeheader.ExplainLessSpecific,
+				mimeheader.ExplainChosen,
+			},
+		},
+		{
+			name:   "Later offer",
+			header: "application/*",
+			ctypes: []string{"application/json", "application/xml"},
+			dtype:  "text/plain",
+			expReasons: []mimeheader.ExplainReason{
+				mimeheader.ExplainChosen,
+				mimeheader.ExplainLaterOffer,
+			},
+		},
+		{
+			name:   "Suffix",
+			header: "application/json",
+			ctypes: []string{"application/problem+json", "application/json"},
+			dtype:  "text/plain",
+			modes:  []mimeheader.MatchMode{mimeheader.MatchSuffix},
+			expReasons: []mimeheader.ExplainReason{
+				mimeheader.ExplainLessSpecific,
+				mimeheader.ExplainChosen,
+			},
+		},
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/aohorodnyk/mimeheader
 
-go 1.16
+go 1.18
@@ -0,0 +1,123 @@
+package mimeheader
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// HTTP header names used by the package.
+const (
+	HeaderAccept      = "Accept"
+	HeaderAcceptPatch = "Accept-Patch"
+	HeaderAcceptPost  = "Accept-Post"
+	HeaderContentType = "Content-Type"
+	HeaderVary        = "Vary"
+)
+
+// NegotiateOptions configures NegotiateMiddleware.
+type NegotiateOptions struct {
+	// Types offered by a handler. They are negotiated like by AcceptHeader.NegotiateResult: on equal quality
+	// the type matched by more specific range wins, then the type matched by the earlier range of Accept header,
+	// then the first type.
+	Types []string
+	// Default type is used when none of Types is acceptable. It can be empty.
+	Default string
+	// NotAcceptable responds with 406 Not Acceptable listing available Types, when none of them is acceptable.
+	// Default type is ignored in this case.
+	NotAcceptable bool
+}
+
+type negotiatedKey struct{}
+
+type negotiated struct {
+	accept   MimeHeader
+	mimeType string
+}
+
+// NegotiateMiddleware negotiates response mime type by Accept header of a request with AcceptHeader.Negotiate.
+// Negotiated accept range and mime type are stored in a request context, use NegotiatedFromContext to get them.
+// Content-Type header of a response is set to the negotiated mime type with params of the offered type,
+// like charset, and Vary header always contains Accept.
+// Request without Accept header accepts any mime type (RFC 9110 Sec 12.5.1).
+func NegotiateMiddleware(opts NegotiateOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			AddVary(rw.Header(), HeaderAccept)
+
+			ah := ParseAcceptHeader(acceptHeader(r))
+
+			res := ah.NegotiateResult(opts.Types, opts.Default)
+			if res.Default && opts.NotAcceptable {
+				writeNotAcceptable(rw, opts.Types)
+
+				return
+			}
+
+			ctype, mtype := res.Offer, res.Offer
+			if !res.Default {
+				ctype, mtype = res.MimeType.StringWithParams(), res.MimeType.String()
+			}
+
+			if ctype != "" {
+				rw.Header().Set(HeaderContentType, ctype)
+			}
+
+			ctx := context.WithValue(r.Context(), negotiatedKey{}, negotiated{accept: res.Accept, mimeType: mtype})
+
+			next.ServeHTTP(rw, r.WithContext(ctx))
+		})
+	}
+}
+
+// NegotiatedFromContext returns accept range and mime type negotiated by NegotiateMiddleware.
+// The last parameter is false, if the context was not passed through NegotiateMiddleware.
+func NegotiatedFromContext(ctx context.Context) (accept MimeHeader, mimeType string, ok bool) {
+	n, ok := ctx.Value(negotiatedKey{}).(negotiated)
+	if !ok {
+		return MimeHeader{}, "", false
+	}
+
+	return n.accept, n.mimeType, true
+}
+
+// AddVary adds the header name to Vary header, if it is not listed yet.
+func AddVary(header http.Header, name string) {
+	for _, value := range header.Values(HeaderVary) {
+		for _, field := range strings.Split(value, ListSeparator) {
+			field = strings.TrimSpace(field)
+			if field == MimeAny || strings.EqualFold(field, name) {
+				return
+			}
+		}
+	}
+
+	header.Add(HeaderVary, name)
+}
+
+// acceptHeader returns Accept header of the request, all header lines are combined.
+// Missing header is replaced by "*/*".
+func acceptHeader(r *http.Request) string {
+	values := r.Header.Values(HeaderAccept)
+	if len(values) == 0 {
+		return MimeAny + MimeSeparator + MimeAny
+	}
+
+	return strings.Join(values, ListSeparator)
+}
+
+// writeNotAcceptable responds with 406 Not Acceptable and the list of available types, one per line.
+func writeNotAcceptable(rw http.ResponseWriter, types []string) {
+	writeTypes(rw, http.StatusNotAcceptable, types)
+}
+
+// writeTypes responds with the status code and the list of types, one per line.
+func writeTypes(rw http.ResponseWriter, code int, types []string) {
+	rw.Header().Set(HeaderContentType, "text/plain; charset=utf-8")
+	rw.Header().Set("X-Content-Type-Options", "nosniff")
+	rw.WriteHeader(code)
+
+	for _, mtype := range types {
+		_, _ = rw.Write([]byte(mtype + "\n"))
+	}
+}
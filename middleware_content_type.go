@@ -0,0 +1,94 @@
+package mimeheader
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ContentTypeOptions configures ContentTypeMiddleware.
+type ContentTypeOptions struct {
+	// Types are allowed media ranges of request bodies by HTTP method, like http.MethodPost.
+	// Requests with other methods are not checked.
+	Types map[string][]MimeType
+}
+
+// ContentTypeMiddleware validates Content-Type header of requests by allowed media ranges of the method with MimeType.Match,
+// params of ranges MUST be in Content-Type (MatchParamsSubset). Request without Content-Type and body is allowed.
+// Not allowed request is rejected with 415 Unsupported Media Type listing allowed ranges.
+// Accept-Post (W3C LDP) and Accept-Patch (RFC 5789 Sec 3.1) headers are set for 415 responses of POST and PATCH requests.
+// Accept-Post and Accept-Patch headers of configured POST and PATCH methods are set for OPTIONS requests as well,
+// which are passed to the next handler to answer them, like with Allow header.
+func ContentTypeMiddleware(opts ContentTypeOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions {
+				for method, mtypes := range opts.Types {
+					if header := acceptMethodHeader(method); header != "" {
+						rw.Header().Set(header, FormatMediaTypes(mtypes))
+					}
+				}
+			}
+
+			mtypes, ok := opts.Types[r.Method]
+			if !ok || allowedContentType(r, mtypes) {
+				next.ServeHTTP(rw, r)
+
+				return
+			}
+
+			if header := acceptMethodHeader(r.Method); header != "" {
+				rw.Header().Set(header, FormatMediaTypes(mtypes))
+			}
+
+			types := make([]string, 0, len(mtypes))
+			for _, mtype := range mtypes {
+				types = append(types, mtype.StringWithParams())
+			}
+
+			writeTypes(rw, http.StatusUnsupportedMediaType, types)
+		})
+	}
+}
+
+// FormatMediaTypes formats the list of media types with params, like for Accept-Post or Accept-Patch headers.
+func FormatMediaTypes(mtypes []MimeType) string {
+	formatted := make([]string, 0, len(mtypes))
+	for _, mtype := range mtypes {
+		formatted = append(formatted, mtype.StringWithParams())
+	}
+
+	return strings.Join(formatted, ListSeparator+" ")
+}
+
+// allowedContentType reports whether Content-Type of the request is matched by one of media ranges.
+func allowedContentType(r *http.Request, mtypes []MimeType) bool {
+	ctype := r.Header.Get(HeaderContentType)
+	if ctype == "" {
+		return r.ContentLength == 0
+	}
+
+	target, err := parseContentType(ctype)
+	if err != nil {
+		return false
+	}
+
+	for _, mtype := range mtypes {
+		if mtype.Match(target, MatchParamsSubset) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// acceptMethodHeader returns header with accepted media types of the method or empty string.
+func acceptMethodHeader(method string) string {
+	switch method {
+	case http.MethodPost:
+		return HeaderAcceptPost
+	case http.MethodPatch:
+		return HeaderAcceptPatch
+	default:
+		return ""
+	}
+}
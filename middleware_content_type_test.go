@@ -0,0 +1,216 @@
+package mimeheader_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleContentTypeMiddleware() {
+	handler := mimeheader.ContentTypeMiddleware(mimeheader.ContentTypeOptions{
+		Types: map[string][]mimeheader.MimeType{
+			http.MethodPost:  {{Type: "application", Subtype: "json"}, {Type: "text", Subtype: "*"}},
+			http.MethodPatch: {{Type: "application", Subtype: "merge-patch+json"}},
+		},
+	})(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Allow", "OPTIONS, POST, PATCH")
+		fmt.Println("Handled:", r.Method)
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}"))
+	r.Header.Set("Content-Type", "application/json")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	r = httptest.NewRequest(http.MethodPatch, "/", strings.NewReader("{}"))
+	r.Header.Set("Content-Type", "application/json")
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, r)
+	fmt.Println(rw.Code, rw.Header().Get("Accept-Patch"))
+
+	r = httptest.NewRequest(http.MethodOptions, "/", nil)
+
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, r)
+	fmt.Println(rw.Code, rw.Header().Get("Allow"))
+	fmt.Println(rw.Header().Get("Accept-Post"))
+	// Output:
+	// Handled: POST
+	// 415 application/merge-patch+json
+	// Handled: OPTIONS
+	// 200 OPTIONS, POST, PATCH
+	// application/json, text/*
+}
+
+func TestContentTypeMiddleware(t *testing.T) {
+	t.Parallel()
+
+	opts := mimeheader.ContentTypeOptions{
+		Types: map[string][]mimeheader.MimeType{
+			http.MethodPost: {
+				{Type: "application", Subtype: "json"},
+				{Type: "text", Subtype: "plain", Params: map[string]string{"charset": "utf-8"}},
+			},
+			http.MethodPut: {{Type: "application", Subtype: "octet-stream"}},
+		},
+	}
+
+	for _, prov := range providerContentTypeMiddleware() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			var called bool
+
+			handler := mimeheader.ContentTypeMiddleware(opts)(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				called = true
+			}))
+
+			r := httptest.NewRequest(prov.method, "/", strings.NewReader(prov.body))
+			if prov.ctype != "" {
+				r.Header.Set("Content-Type", prov.ctype)
+			}
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, r)
+
+			if rw.Code != prov.expCode {
+				t.Errorf("Unexpected status code.\nExpected: %d\nActual: %d", prov.expCode, rw.Code)
+			}
+
+			if called != prov.expCalled {
+				t.Errorf("Unexpected next handler call.\nExpected: %t\nActual: %t", prov.expCalled, called)
+			}
+
+			if act := rw.Header().Get("Accept-Post"); act != prov.expAcceptPost {
+				t.Errorf("Unexpected Accept-Post.\nExpected: %s\nActual: %s", prov.expAcceptPost, act)
+			}
+
+			if act := rw.Body.String(); act != prov.expBody {
+				t.Errorf("Unexpected body.\nExpected: %q\nActual: %q", prov.expBody, act)
+			}
+		})
+	}
+}
+
+type contentTypeMiddleware struct {
+	name          string
+	method        string
+	ctype         string
+	body          string
+	expCode       int
+	expCalled     bool
+	expAcceptPost string
+	expBody       string
+}
+
+func providerContentTypeMiddleware() []contentTypeMiddleware {
+	return []contentTypeMiddleware{
+		{
+			name:      "Not checked method",
+			method:    http.MethodGet,
+			ctype:     "image/png",
+			expCode:   http.StatusOK,
+			expCalled: true,
+		},
+		{
+			name:      "Allowed type",
+			method:    http.MethodPost,
+			ctype:     "Application/JSON; charset=utf-8",
+			body:      "{}",
+			expCode:   http.StatusOK,
+			expCalled: true,
+		},
+		{
+			name:      "Allowed params",
+			method:    http.MethodPost,
+			ctype:     "text/plain; charset=UTF8",
+			body:      "text",
+			expCode:   http.StatusOK,
+			expCalled: true,
+		},
+		{
+			name:          "Missed params",
+			method:        http.MethodPost,
+			ctype:         "text/plain",
+			body:          "text",
+			expCode:       http.StatusUnsupportedMediaType,
+			expAcceptPost: "application/json, text/plain; charset=utf-8",
+			expBody:       "application/json\ntext/plain; charset=utf-8\n",
+		},
+		{
+			name:      "Missed Content-Type without body",
+			method:    http.MethodPost,
+			expCode:   http.StatusOK,
+			expCalled: true,
+		},
+		{
+			name:          "Missed Content-Type with body",
+			method:        http.MethodPost,
+			body:          "{}",
+			expCode:       http.StatusUnsupportedMediaType,
+			expAcceptPost: "application/json, text/plain; charset=utf-8",
+			expBody:       "application/json\ntext/plain; charset=utf-8\n",
+		},
+		{
+			name:    "Wildcard Content-Type",
+			method:  http.MethodPut,
+			ctype:   "*/*",
+			body:    "data",
+			expCode: http.StatusUnsupportedMediaType,
+			expBody: "application/octet-stream\n",
+		},
+		{
+			name:          "Options",
+			method:        http.MethodOptions,
+			expCode:       http.StatusOK,
+			expCalled:     true,
+			expAcceptPost: "application/json, text/plain; charset=utf-8",
+		},
+	}
+}
+
+func TestContentTypeMiddlewareOptionsWithoutTypes(t *testing.T) {
+	t.Parallel()
+
+	opts := mimeheader.ContentTypeOptions{
+		Types: map[string][]mimeheader.MimeType{http.MethodPut: {{Type: "application", Subtype: "octet-stream"}}},
+	}
+
+	handler := mimeheader.ContentTypeMiddleware(opts)(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Allow", "OPTIONS, PUT")
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodOptions, "/", nil))
+
+	if rw.Code != http.StatusNoContent || rw.Header().Get("Allow") != "OPTIONS, PUT" {
+		t.Fatalf("OPTIONS request is not answered by the next handler: %d %v", rw.Code, rw.Header())
+	}
+
+	if rw.Header().Get("Accept-Post") != "" || rw.Header().Get("Accept-Patch") != "" {
+		t.Fatalf("Accept-Post or Accept-Patch is set without configured types: %v", rw.Header())
+	}
+}
+
+func TestFormatMediaTypes(t *testing.T) {
+	t.Parallel()
+
+	act := mimeheader.FormatMediaTypes([]mimeheader.MimeType{
+		{Type: "text", Subtype: "*"},
+		{Type: "application", Subtype: "vnd.api+json", Params: map[string]string{"ext": "https://example.com/ext"}},
+	})
+
+	if exp := `text/*, application/vnd.api+json; ext="https://example.com/ext"`; act != exp {
+		t.Fatalf("Wrong formatted types.\nExpected: %s\nActual: %s", exp, act)
+	}
+
+	if act := mimeheader.FormatMediaTypes(nil); act != "" {
+		t.Fatalf("Wrong formatted empty list: %s", act)
+	}
+}
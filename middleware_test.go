@@ -0,0 +1,213 @@
+package mimeheader_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleNegotiateMiddleware() {
+	handler := mimeheader.NegotiateMiddleware(mimeheader.NegotiateOptions{
+		Types:         []string{"application/json", "text/html"},
+		NotAcceptable: true,
+	})(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		_, mtype, _ := mimeheader.NegotiatedFromContext(r.Context())
+		fmt.Println("Negotiated:", mtype)
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "text/*")
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, r)
+	fmt.Println(rw.Code, rw.Header().Get("Content-Type"), rw.Header().Get("Vary"))
+
+	r.Header.Set("Accept", "image/png")
+
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, r)
+	fmt.Print(rw.Code, " ", rw.Body.String())
+	// Output:
+	// Negotiated: text/html
+	// 200 text/html Accept
+	// 406 application/json
+	// text/html
+}
+
+func TestNegotiateMiddleware(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerNegotiateMiddleware() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				called   bool
+				actMType string
+				actOK    bool
+			)
+
+			handler := mimeheader.NegotiateMiddleware(prov.opts)(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				called = true
+				_, actMType, actOK = mimeheader.NegotiatedFromContext(r.Context())
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			for _, accept := range prov.accept {
+				r.Header.Add("Accept", accept)
+			}
+
+			rw := httptest.NewRecorder()
+			if prov.vary != "" {
+				rw.Header().Set("Vary", prov.vary)
+			}
+			handler.ServeHTTP(rw, r)
+
+			if rw.Code != prov.expCode {
+				t.Errorf("Unexpected status code.\nExpected: %d\nActual: %d", prov.expCode, rw.Code)
+			}
+
+			if called != prov.expCalled || actOK != prov.expCalled {
+				t.Errorf("Unexpected next handler call.\nExpected: %t\nActual: %t", prov.expCalled, called)
+			}
+
+			if actMType != prov.expMType {
+				t.Errorf("Unexpected mime type in context.\nExpected: %s\nActual: %s", prov.expMType, actMType)
+			}
+
+			if act := rw.Header().Get("Content-Type"); act != prov.expContentType {
+				t.Errorf("Unexpected Content-Type.\nExpected: %s\nActual: %s", prov.expContentType, act)
+			}
+
+			if act := rw.Header().Values("Vary"); !reflect.DeepEqual(act, prov.expVary) {
+				t.Errorf("Unexpected Vary.\nExpected: %v\nActual: %v", prov.expVary, act)
+			}
+
+			if act := rw.Body.String(); act != prov.expBody {
+				t.Errorf("Unexpected body.\nExpected: %q\nActual: %q", prov.expBody, act)
+			}
+		})
+	}
+}
+
+func TestNegotiatedFromContext(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	accept, mtype, ok := mimeheader.NegotiatedFromContext(r.Context())
+	if ok || mtype != "" || accept.String() != "" {
+		t.Fatalf("Unexpected negotiated values without middleware: %v, %s, %t", accept, mtype, ok)
+	}
+}
+
+type negotiateMiddleware struct {
+	name           string
+	opts           mimeheader.NegotiateOptions
+	accept         []string
+	vary           string
+	expCode        int
+	expCalled      bool
+	expMType       string
+	expContentType string
+	expVary        []string
+	expBody        string
+}
+
+func providerNegotiateMiddleware() []negotiateMiddleware {
+	return []negotiateMiddleware{
+		{
+			name:           "Missing Accept header accepts any type",
+			opts:           mimeheader.NegotiateOptions{Types: []string{"application/json"}, NotAcceptable: true},
+			expCode:        http.StatusOK,
+			expCalled:      true,
+			expMType:       "application/json",
+			expContentType: "application/json",
+			expVary:        []string{"Accept"},
+		},
+		{
+			name:           "Multiple Accept headers",
+			opts:           mimeheader.NegotiateOptions{Types: []string{"application/json", "text/html"}, NotAcceptable: true},
+			accept:         []string{"application/json;q=0.5", "text/html"},
+			expCode:        http.StatusOK,
+			expCalled:      true,
+			expMType:       "text/html",
+			expContentType: "text/html",
+			expVary:        []string{"Accept"},
+		},
+		{
+			name:           "Params of offered type",
+			opts:           mimeheader.NegotiateOptions{Types: []string{"application/json;qs=0.9", "text/html; charset=utf-8"}},
+			accept:         []string{"text/*, application/json"},
+			expCode:        http.StatusOK,
+			expCalled:      true,
+			expMType:       "text/html",
+			expContentType: "text/html; charset=utf-8",
+			expVary:        []string{"Accept"},
+		},
+		{
+			name:           "Earlier range on equal quality",
+			opts:           mimeheader.NegotiateOptions{Types: []string{"application/json", "application/xml"}},
+			accept:         []string{"application/xml, application/json"},
+			expCode:        http.StatusOK,
+			expCalled:      true,
+			expMType:       "application/xml",
+			expContentType: "application/xml",
+			expVary:        []string{"Accept"},
+		},
+		{
+			name:           "First type on equal range",
+			opts:           mimeheader.NegotiateOptions{Types: []string{"application/json", "application/xml"}},
+			accept:         []string{"application/*"},
+			expCode:        http.StatusOK,
+			expCalled:      true,
+			expMType:       "application/json",
+			expContentType: "application/json",
+			expVary:        []string{"Accept"},
+		},
+		{
+			name:           "Default type",
+			opts:           mimeheader.NegotiateOptions{Types: []string{"application/json"}, Default: "text/plain"},
+			accept:         []string{"image/*"},
+			expCode:        http.StatusOK,
+			expCalled:      true,
+			expMType:       "text/plain",
+			expContentType: "text/plain",
+			expVary:        []string{"Accept"},
+		},
+		{
+			name:      "Empty default type",
+			opts:      mimeheader.NegotiateOptions{Types: []string{"application/json"}},
+			accept:    []string{"image/*"},
+			expCode:   http.StatusOK,
+			expCalled: true,
+			expVary:   []string{"Accept"},
+		},
+		{
+			name:           "Not acceptable",
+			opts:           mimeheader.NegotiateOptions{Types: []string{"application/json", "text/html"}, Default: "text/plain", NotAcceptable: true},
+			accept:         []string{"*/*;q=0"},
+			vary:           "Origin",
+			expCode:        http.StatusNotAcceptable,
+			expContentType: "text/plain; charset=utf-8",
+			expVary:        []string{"Origin", "Accept"},
+			expBody:        "application/json\ntext/html\n",
+		},
+		{
+			name:           "Vary already contains Accept",
+			opts:           mimeheader.NegotiateOptions{Types: []string{"application/json"}},
+			accept:         []string{"application/json"},
+			vary:           "Origin, accept",
+			expCode:        http.StatusOK,
+			expCalled:      true,
+			expMType:       "application/json",
+			expContentType: "application/json",
+			expVary:        []string{"Origin, accept"},
+		},
+	}
+}
@@ -1,6 +1,9 @@
 package mimeheader
 
-import "mime"
+import (
+	"mime"
+	"strings"
+)
 
 // MimeParts MUST contain two parts <MIME_type>/<MIME_subtype>.
 const MimeParts = 2
@@ -11,6 +14,19 @@ const MimeSeparator = "/"
 // MimeAny represented as the asterisk.
 const MimeAny = "*"
 
+// SuffixSeparator separates structured syntax suffix of a subtype, like "json" in "problem+json" (RFC 6839).
+const SuffixSeparator = "+"
+
+// FacetSeparator separates facet (registration tree) of a subtype, like "vnd" in "vnd.api+json" (RFC 6838 Sec 3).
+const FacetSeparator = "."
+
+// Facets of registration trees (RFC 6838 Sec 3).
+const (
+	FacetVendor       = "vnd"
+	FacetPersonal     = "prs"
+	FacetUnregistered = "x"
+)
+
 // MimeType structure for media type (mime type).
 type MimeType struct {
 	Type    string
@@ -51,34 +67,109 @@ func (mt MimeType) String() string {
 	return t + MimeSeparator + st
 }
 
+// Suffix returns structured syntax suffix of the subtype, like "json" for "application/problem+json" (RFC 6839).
+// Empty string is returned if the subtype has no suffix.
+func (mt MimeType) Suffix() string {
+	idx := strings.LastIndex(mt.Subtype, SuffixSeparator)
+	if idx < 0 {
+		return ""
+	}
+
+	return mt.Subtype[idx+1:]
+}
+
+// BaseSubtype returns the subtype without structured syntax suffix, like "vnd.acme.order" for "vnd.acme.order+json".
+func (mt MimeType) BaseSubtype() string {
+	idx := strings.LastIndex(mt.Subtype, SuffixSeparator)
+	if idx < 0 {
+		return mt.Subtype
+	}
+
+	return mt.Subtype[:idx]
+}
+
+// Facet returns registration tree of the subtype: FacetVendor, FacetPersonal or FacetUnregistered.
+// Empty string is returned for the standards tree (RFC 6838 Sec 3).
+// Subtypes with "x-" prefix belong to the unregistered tree as well.
+func (mt MimeType) Facet() string {
+	subtype := strings.ToLower(mt.Subtype)
+
+	if strings.HasPrefix(subtype, FacetUnregistered+"-") {
+		return FacetUnregistered
+	}
+
+	idx := strings.Index(subtype, FacetSeparator)
+	if idx < 0 {
+		return ""
+	}
+
+	switch facet := subtype[:idx]; facet {
+	case FacetVendor, FacetPersonal, FacetUnregistered:
+		return facet
+	default:
+		return ""
+	}
+}
+
 // StringWithParams builds mime type from type and subtype with params.
 func (mt MimeType) StringWithParams() string {
 	return mime.FormatMediaType(mt.String(), mt.Params)
 }
 
+// MatchMode configures how parameters of media types are compared by matching functions.
+// Modes can be combined with bitwise OR, MatchParamsExact has higher priority than MatchParamsSubset.
+type MatchMode uint8
+
+const (
+	// MatchIgnoreParams compares only type and subtype. It's the default mode.
+	MatchIgnoreParams MatchMode = 0
+	// MatchParamsSubset requires all params of the current (accept) type to be in the target type with equal values.
+	MatchParamsSubset MatchMode = 1
+	// MatchParamsExact requires the same set of params with equal values in both types.
+	MatchParamsExact MatchMode = 2
+	// MatchSuffix allows a range of a base type to match a type with the structured syntax suffix (RFC 6839),
+	// like "application/json" matches "application/problem+json".
+	// Suffix match has less precedence than a match of the exact subtype, but higher than "type/*".
+	MatchSuffix MatchMode = 4
+)
+
 // Match matches current structure with possible wildcards.
 // MimeType structure (current) can be wildcard or specific type, like "text/*", "*/*", "text/plain".
-func (mt MimeType) Match(target MimeType) bool {
-	if !matchMimePart(mt.Type, target.Type) {
-		return false
-	}
-
-	if !matchMimePart(mt.Subtype, target.Subtype) {
-		return false
-	}
+// Params are ignored by default, modes enable params comparison. Names of params are case-insensitive,
+// values are case-sensitive except of charset, which is compared with resolved IANA aliases.
+func (mt MimeType) Match(target MimeType, modes ...MatchMode) bool {
+	matched, _ := mt.match(target, mergeModes(modes))
 
-	return true
+	return matched
 }
 
 // MatchText matches current structure with possible wildcards. Target MUST be specific type, like "application/json", "text/plain"
 // MimeType structure (current) can be wildcard or specific type, like "text/*", "*/*", "text/plain".
-func (mt MimeType) MatchText(target string) bool {
+// Modes have the same meaning as for MimeType.Match.
+func (mt MimeType) MatchText(target string, modes ...MatchMode) bool {
 	tmtype, err := ParseMediaType(target)
 	if err != nil {
 		return false
 	}
 
-	return mt.Match(tmtype)
+	return mt.Match(tmtype, modes...)
+}
+
+// match matches current structure with the target, the second parameter reports whether subtype matched by suffix.
+func (mt MimeType) match(target MimeType, mode MatchMode) (matched, suffix bool) {
+	if !matchMimePart(mt.Type, target.Type) {
+		return false, false
+	}
+
+	if !matchMimePart(mt.Subtype, target.Subtype) {
+		if mode&MatchSuffix == 0 || mt.Subtype != target.Suffix() {
+			return false, false
+		}
+
+		suffix = true
+	}
+
+	return matchParams(mt.Params, target.Params, mode), suffix
 }
 
 func matchMimePart(b, t string) bool {
@@ -92,3 +183,61 @@ func matchMimePart(b, t string) bool {
 
 	return false
 }
+
+func mergeModes(modes []MatchMode) MatchMode {
+	var mode MatchMode
+
+	for _, m := range modes {
+		mode |= m
+	}
+
+	return mode
+}
+
+// matchParams matches params of base type with params of target type by the mode.
+func matchParams(b, t map[string]string, mode MatchMode) bool {
+	switch {
+	case mode&MatchParamsExact != 0:
+		return len(b) == len(t) && subsetParams(b, t)
+	case mode&MatchParamsSubset != 0:
+		return subsetParams(b, t)
+	default:
+		return true
+	}
+}
+
+// subsetParams reports whether all params of b are in t with equal values.
+func subsetParams(b, t map[string]string) bool {
+	for name, value := range b {
+		tvalue, ok := lookupParam(t, name)
+		if !ok || !equalParamValues(name, value, tvalue) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// lookupParam returns value of the param by case-insensitive name.
+func lookupParam(params map[string]string, name string) (string, bool) {
+	if value, ok := params[name]; ok {
+		return value, true
+	}
+
+	for pname, value := range params {
+		if strings.EqualFold(pname, name) {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// equalParamValues compares values of the param, charset values are case-insensitive.
+func equalParamValues(name, a, b string) bool {
+	if strings.EqualFold(name, CharsetParam) {
+		return equalCharsets(a, b)
+	}
+
+	return a == b
+}
@@ -0,0 +1,115 @@
+package mimeheader_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleMatchMode() {
+	mtype, err := mimeheader.ParseMediaType("text/html; level=1")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(mtype.MatchText("text/html; level=2"))
+	fmt.Println(mtype.MatchText("text/html; level=2", mimeheader.MatchParamsSubset))
+	fmt.Println(mtype.MatchText("text/html; level=1; charset=utf-8", mimeheader.MatchParamsSubset))
+	fmt.Println(mtype.MatchText("text/html; level=1; charset=utf-8", mimeheader.MatchParamsExact))
+	// Output:
+	// true
+	// false
+	// true
+	// false
+}
+
+func TestMimeType_MatchModes(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerMimeTypeMatchModes() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := mimeheader.ParseMediaType(prov.b)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			act := b.MatchText(prov.t, prov.modes...)
+			if prov.exp != act {
+				t.Fatalf("Match is not equal to expected value. Expected: %t. Actual: %t", prov.exp, act)
+			}
+		})
+	}
+}
+
+type mimeTypeMatchModes struct {
+	name  string
+	b     string
+	t     string
+	modes []mimeheader.MatchMode
+	exp   bool
+}
+
+func providerMimeTypeMatchModes() []mimeTypeMatchModes {
+	return []mimeTypeMatchModes{
+		{
+			name:  "Ignore params",
+			b:     "text/html;level=1",
+			t:     "text/html;level=2",
+			modes: []mimeheader.MatchMode{mimeheader.MatchIgnoreParams},
+			exp:   true,
+		},
+		{
+			name:  "Subset without params",
+			b:     "text/*",
+			t:     "text/html;level=2",
+			modes: []mimeheader.MatchMode{mimeheader.MatchParamsSubset},
+			exp:   true,
+		},
+		{
+			name:  "Subset with missed param",
+			b:     "text/html;level=1",
+			t:     "text/html",
+			modes: []mimeheader.MatchMode{mimeheader.MatchParamsSubset},
+			exp:   false,
+		},
+		{
+			name:  "Subset with case-sensitive value",
+			b:     "multipart/form-data;boundary=ABC",
+			t:     "multipart/form-data;boundary=abc",
+			modes: []mimeheader.MatchMode{mimeheader.MatchParamsSubset},
+			exp:   false,
+		},
+		{
+			name:  "Subset with charset alias",
+			b:     "text/html;CHARSET=latin1",
+			t:     "text/html;charset=ISO-8859-1;level=1",
+			modes: []mimeheader.MatchMode{mimeheader.MatchParamsSubset},
+			exp:   true,
+		},
+		{
+			name:  "Exact with extra param",
+			b:     "text/html;charset=utf-8",
+			t:     "text/html;charset=UTF-8;level=1",
+			modes: []mimeheader.MatchMode{mimeheader.MatchParamsExact},
+			exp:   false,
+		},
+		{
+			name:  "Exact has higher priority than subset",
+			b:     "text/html;charset=utf-8",
+			t:     "text/html;charset=utf-8;level=1",
+			modes: []mimeheader.MatchMode{mimeheader.MatchParamsSubset, mimeheader.MatchParamsExact},
+			exp:   false,
+		},
+		{
+			name:  "Exact",
+			b:     "text/*;charset=utf-8;level=1",
+			t:     "text/html;level=1;charset=UTF8",
+			modes: []mimeheader.MatchMode{mimeheader.MatchParamsExact},
+			exp:   true,
+		},
+	}
+}
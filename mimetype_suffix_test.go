@@ -0,0 +1,115 @@
+package mimeheader_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleMimeType_Suffix() {
+	mtype, err := mimeheader.ParseMediaType("application/vnd.acme.order+json")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(mtype.Facet(), mtype.BaseSubtype(), mtype.Suffix())
+
+	ah := mimeheader.ParseAcceptHeader("application/json")
+	fmt.Println(ah.Match(mtype.String()))
+	fmt.Println(ah.Match(mtype.String(), mimeheader.MatchSuffix))
+	// Output:
+	// vnd vnd.acme.order json
+	// false
+	// true
+}
+
+func TestMimeType_Suffix(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerMimeTypeSuffix() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			if act := prov.mtype.Suffix(); act != prov.expSuffix {
+				t.Errorf("Wrong suffix.\nExpected: %s\nActual: %s", prov.expSuffix, act)
+			}
+
+			if act := prov.mtype.Facet(); act != prov.expFacet {
+				t.Errorf("Wrong facet.\nExpected: %s\nActual: %s", prov.expFacet, act)
+			}
+
+			if act := prov.mtype.BaseSubtype(); act != prov.expBase {
+				t.Errorf("Wrong base subtype.\nExpected: %s\nActual: %s", prov.expBase, act)
+			}
+		})
+	}
+}
+
+type mimeTypeSuffix struct {
+	name      string
+	mtype     mimeheader.MimeType
+	expSuffix string
+	expFacet  string
+	expBase   string
+}
+
+func providerMimeTypeSuffix() []mimeTypeSuffix {
+	return []mimeTypeSuffix{
+		{
+			name:  "Empty",
+			mtype: mimeheader.MimeType{},
+		},
+		{
+			name:    "Standards tree without suffix",
+			mtype:   mimeheader.MimeType{Type: "application", Subtype: "json"},
+			expBase: "json",
+		},
+		{
+			name:      "Standards tree with suffix",
+			mtype:     mimeheader.MimeType{Type: "application", Subtype: "problem+json"},
+			expSuffix: "json",
+			expBase:   "problem",
+		},
+		{
+			name:      "Vendor tree",
+			mtype:     mimeheader.MimeType{Type: "application", Subtype: "vnd.acme.order+json"},
+			expSuffix: "json",
+			expFacet:  "vnd",
+			expBase:   "vnd.acme.order",
+		},
+		{
+			name:     "Personal tree",
+			mtype:    mimeheader.MimeType{Type: "image", Subtype: "prs.btif"},
+			expFacet: "prs",
+			expBase:  "prs.btif",
+		},
+		{
+			name:      "Unregistered tree",
+			mtype:     mimeheader.MimeType{Type: "application", Subtype: "x.foo+cbor"},
+			expSuffix: "cbor",
+			expFacet:  "x",
+			expBase:   "x.foo",
+		},
+		{
+			name:     "Unregistered prefix",
+			mtype:    mimeheader.MimeType{Type: "application", Subtype: "x-www-form-urlencoded"},
+			expFacet: "x",
+			expBase:  "x-www-form-urlencoded",
+		},
+		{
+			name:      "Unknown facet",
+			mtype:     mimeheader.MimeType{Type: "application", Subtype: "example.foo+xml"},
+			expSuffix: "xml",
+			expBase:   "example.foo",
+		},
+		{
+			name:      "Multiple suffixes",
+			mtype:     mimeheader.MimeType{Type: "application", Subtype: "vnd.example+json+zip"},
+			expSuffix: "zip",
+			expFacet:  "vnd",
+			expBase:   "vnd.example+json",
+		},
+	}
+}
@@ -0,0 +1,155 @@
+package mimeheader
+
+// OfferErrMsg is an error message for invalid offered mime type.
+const OfferErrMsg = "invalid offered mime type"
+
+// negotiatorStackOffers is a number of offers which Negotiator handles without allocations.
+const negotiatorStackOffers = 16
+
+// Negotiator negotiates mime type for Accept header from precompiled list of offered (common) mime types.
+// It has the same rules as AcceptHeader.Negotiate, but offered types are parsed and validated only once on creation
+// and Accept header is scanned in a single pass.
+// Negotiator is safe for concurrent use.
+type Negotiator struct {
+	offers []negotiatorOffer
+	dtype  string
+	// dresult is a precompiled result with the default type.
+	dresult NegotiationResult
+	mode    MatchMode
+}
+
+type negotiatorOffer struct {
+	offer string
+	mtype MimeType
+	str   string
+	// qs is a source quality of the offered type.
+	qs float32
+}
+
+// NewNegotiator creates Negotiator for offered mime types, default type and match modes, like for AcceptHeader.Negotiate.
+// OfferErr is returned for the first invalid type or type with invalid source quality, like "image/png;qs=5".
+func NewNegotiator(ctypes []string, dtype string, modes ...MatchMode) (*Negotiator, error) {
+	offers := make([]negotiatorOffer, 0, len(ctypes))
+
+	for _, ctype := range ctypes {
+		mtype, err := ParseMediaType(ctype)
+		if err != nil {
+			return nil, OfferErr{Err: err, Msg: OfferErrMsg, Offer: ctype}
+		}
+
+		qs, err := cutSourceQuality(mtype)
+		if err != nil {
+			return nil, OfferErr{Err: err, Msg: OfferErrMsg, Offer: ctype}
+		}
+
+		offers = append(offers, negotiatorOffer{offer: ctype, mtype: mtype, str: mtype.String(), qs: qs})
+	}
+
+	return &Negotiator{offers: offers, dtype: dtype, dresult: defaultResult(dtype), mode: mergeModes(modes)}, nil
+}
+
+// Negotiate parses Accept header and returns the same values as AcceptHeader.Negotiate.
+// It doesn't allocate memory if the matched range has no media type params or accept extensions
+// and there are no more than 16 offered types.
+// Params and Extensions of returned MimeHeader are nil, if the matched range has no media type params or accept extensions.
+func (n *Negotiator) Negotiate(header string) (accept MimeHeader, mimeType string, matched bool) {
+	res, oid := n.negotiate(header)
+	if oid < 0 {
+		return MimeHeader{}, n.dtype, false
+	}
+
+	return res.Accept, n.offers[oid].str, true
+}
+
+// NegotiateResult parses Accept header and returns the same result as AcceptHeader.NegotiateResult.
+// It has the same allocation guarantees as Negotiator.Negotiate.
+// Params of MimeType are shared between results, they MUST NOT be modified.
+func (n *Negotiator) NegotiateResult(header string) NegotiationResult {
+	res, _ := n.negotiate(header)
+
+	return res
+}
+
+// negotiate returns negotiation result and index of the negotiated offer or -1 if the default type is used.
+func (n *Negotiator) negotiate(header string) (NegotiationResult, int) {
+	// The most specific range for every offered type.
+	var buf [negotiatorStackOffers]scannedRange
+
+	best := buf[:]
+	if len(n.offers) > len(buf) {
+		best = make([]scannedRange, len(n.offers))
+	}
+
+	scanner := newRangeScanner(header)
+
+	for sr, ok := scanner.next(); ok; sr, ok = scanner.next() {
+		if sr.reason != scanOK {
+			continue
+		}
+
+		for oid := range n.offers {
+			matched, suffix := sr.match(n.offers[oid].mtype, n.mode)
+			if !matched {
+				continue
+			}
+
+			osr := sr
+			osr.suffix = suffix
+
+			// On equal specificity the range with higher quality has higher precedence, then the first one.
+			cur := best[oid]
+			if cur.typ == "" || cur.lessSpecific(osr) || (!osr.lessSpecific(cur) && osr.quality > cur.quality) {
+				best[oid] = osr
+			}
+		}
+	}
+
+	win, wquality := -1, float32(0)
+
+	for oid := range n.offers {
+		quality := best[oid].quality * n.offers[oid].qs
+		if best[oid].typ == "" || quality <= 0 {
+			continue
+		}
+
+		if win < 0 || preferredRange(best[oid], quality, best[win], wquality) {
+			win, wquality = oid, quality
+		}
+	}
+
+	if win < 0 {
+		return n.dresult, -1
+	}
+
+	res := NegotiationResult{
+		Offer:    n.offers[win].offer,
+		MimeType: n.offers[win].mtype,
+		Accept:   best[win].mimeHeader(),
+		Quality:  wquality,
+	}
+
+	return res, win
+}
+
+// preferredRange reports whether range a has higher precedence than range b by the same rules as AcceptHeader.preferred.
+// Qualities are effective qualities of offered types matched by the ranges.
+func preferredRange(a scannedRange, aquality float32, b scannedRange, bquality float32) bool {
+	if aquality != bquality {
+		return aquality > bquality
+	}
+
+	if b.lessSpecific(a) {
+		return true
+	}
+
+	if a.lessSpecific(b) {
+		return false
+	}
+
+	// Ranges are sorted by quality in AcceptHeader, so the range with higher quality goes first.
+	if a.quality != b.quality {
+		return a.quality > b.quality
+	}
+
+	return a.offset < b.offset
+}
@@ -0,0 +1,248 @@
+package mimeheader_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleNegotiator_Negotiate() {
+	negotiator, err := mimeheader.NewNegotiator([]string{"application/json", "text/html"}, "text/plain")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(negotiator.Negotiate("text/*;q=0.9, application/json;q=0.5"))
+	fmt.Println(negotiator.Negotiate("image/*"))
+	// Output:
+	// text/* text/html true
+	//  text/plain false
+}
+
+func TestNewNegotiator(t *testing.T) {
+	t.Parallel()
+
+	_, err := mimeheader.NewNegotiator([]string{"application/json", "*/plain"}, "")
+
+	var offerErr mimeheader.OfferErr
+	if !errors.As(err, &offerErr) || offerErr.Offer != "*/plain" {
+		t.Fatalf("Unexpected error.\nExpected: %T for */plain\nActual: %#v", offerErr, err)
+	}
+
+	var wildcardErr mimeheader.MimeTypeWildcardErr
+	if !errors.As(err, &wildcardErr) {
+		t.Fatalf("Unexpected wrapped error.\nExpected: %T\nActual: %#v", wildcardErr, offerErr.Err)
+	}
+}
+
+func TestNewNegotiatorSourceQuality(t *testing.T) {
+	t.Parallel()
+
+	for _, offer := range []string{"image/png;qs=abc", "image/gif;qs=-1", "image/webp;qs=5", "image/jpeg;qs=0.1234"} {
+		_, err := mimeheader.NewNegotiator([]string{"application/json", offer}, "")
+
+		var offerErr mimeheader.OfferErr
+		if !errors.As(err, &offerErr) || offerErr.Offer != offer {
+			t.Fatalf("Unexpected error for %s.\nExpected: %T\nActual: %#v", offer, offerErr, err)
+		}
+
+		var qsErr mimeheader.SourceQualityErr
+		if !errors.As(err, &qsErr) {
+			t.Fatalf("Unexpected wrapped error for %s.\nExpected: %T\nActual: %#v", offer, qsErr, offerErr.Err)
+		}
+	}
+
+	if _, err := mimeheader.NewNegotiator([]string{"image/png;qs=0", "image/gif;QS=1.000"}, ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestNegotiator_Negotiate(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerNegotiatorNegotiate() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			negotiator, err := mimeheader.NewNegotiator(prov.ctypes, prov.dtype, prov.modes...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			actHeader, actMType, actMatched := negotiator.Negotiate(prov.header)
+			expHeader, expMType, expMatched := mimeheader.ParseAcceptHeader(prov.header).Negotiate(prov.ctypes, prov.dtype, prov.modes...)
+
+			if actHeader.StringWithParams() != expHeader.StringWithParams() || actHeader.Quality != expHeader.Quality {
+				t.Errorf("Wrong header matched.\nExpected: %v\nActual: %v", expHeader, actHeader)
+			}
+
+			if actMType != expMType || actMType != prov.expMType {
+				t.Errorf("Wrong mime type returned.\nExpected: %s\nAcceptHeader: %s\nActual: %s", prov.expMType, expMType, actMType)
+			}
+
+			if actMatched != expMatched {
+				t.Errorf("Unepected match result.\nExpected: %t\nActual: %t", expMatched, actMatched)
+			}
+
+			actResult := negotiator.NegotiateResult(prov.header)
+			expResult := mimeheader.ParseAcceptHeader(prov.header).NegotiateResult(prov.ctypes, prov.dtype, prov.modes...)
+
+			if actResult.Offer != expResult.Offer || actResult.Quality != expResult.Quality || actResult.Default != expResult.Default ||
+				actResult.MimeType.StringWithParams() != expResult.MimeType.StringWithParams() {
+				t.Errorf("Wrong negotiation result.\nExpected: %+v\nActual: %+v", expResult, actResult)
+			}
+		})
+	}
+}
+
+func TestNegotiator_NegotiateAllocs(t *testing.T) {
+	negotiator, err := mimeheader.NewNegotiator([]string{"application/xml", "application/json", "text/html"}, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		negotiator.Negotiate("text/html, application/xhtml+xml, application/json, image/*, */*")
+	})
+
+	if allocs != 0 {
+		t.Fatalf("Unexpected allocations.\nExpected: 0\nActual: %v", allocs)
+	}
+}
+
+type negotiatorNegotiate struct {
+	name     string
+	header   string
+	ctypes   []string
+	dtype    string
+	modes    []mimeheader.MatchMode
+	expMType string
+}
+
+func providerNegotiatorNegotiate() []negotiatorNegotiate {
+	return []negotiatorNegotiate{
+		{
+			name:     "Empty header",
+			header:   "",
+			ctypes:   []string{"application/json"},
+			dtype:    "text/plain",
+			expMType: "text/plain",
+		},
+		{
+			name:     "Empty ctypes",
+			header:   "*/*",
+			ctypes:   []string{},
+			dtype:    "text/plain",
+			expMType: "text/plain",
+		},
+		{
+			name:     "Wildcard",
+			header:   "*/*",
+			ctypes:   []string{"application/json;param=1", "text/html"},
+			dtype:    "text/plain",
+			expMType: "application/json",
+		},
+		{
+			name:     "Sorted list of types with the same structure image/png",
+			header:   "application/json;q=1.0,*/*;q=1.0; param=wild,image/png;param=test;q=1.0",
+			ctypes:   []string{"application/json;param=1", "image/png"},
+			dtype:    "text/plain",
+			expMType: "image/png",
+		},
+		{
+			name:     "Most specific range",
+			header:   "image/*; q=0.9; s=4, application/json; q=0.9; b=3;, text/plain,image/png;q=0.9, image/jpeg,image/svg;q=0.8",
+			ctypes:   []string{"application/xml", "image/svg"},
+			dtype:    "text/javascript",
+			expMType: "image/svg",
+		},
+		{
+			name:     "Zero quality",
+			header:   "text/*;q=0.5, text/html;q=0",
+			ctypes:   []string{"text/html", "text/plain"},
+			dtype:    "text/javascript",
+			expMType: "text/plain",
+		},
+		{
+			name:     "Duplicated ranges",
+			header:   "text/html;q=0.2, application/json;q=0.5, text/html;q=0.7",
+			ctypes:   []string{"application/json", "text/html"},
+			dtype:    "text/javascript",
+			expMType: "text/html",
+		},
+		{
+			name:     "Upper case and invalid ranges",
+			header:   "TEXT/HTML;Level=1;Q=0.5, */plain, text/plain;;, {}, application/json;q=0.4",
+			ctypes:   []string{"application/json", "text/html"},
+			dtype:    "text/javascript",
+			expMType: "text/html",
+		},
+		{
+			name:     "Equal quality resolved by header order",
+			header:   "application/json, text/html",
+			ctypes:   []string{"text/html", "application/json"},
+			dtype:    "text/javascript",
+			expMType: "application/json",
+		},
+		{
+			name:   "More offers than stack buffer",
+			header: "application/x-17",
+			ctypes: []string{
+				"application/x-1", "application/x-2", "application/x-3", "application/x-4", "application/x-5", "application/x-6",
+				"application/x-7", "application/x-8", "application/x-9", "application/x-10", "application/x-11", "application/x-12",
+				"application/x-13", "application/x-14", "application/x-15", "application/x-16", "application/x-17",
+			},
+			dtype:    "text/javascript",
+			expMType: "application/x-17",
+		},
+		{
+			name:     "Params subset",
+			header:   "text/*;q=0.3, text/html;q=0.7, text/html;LEVEL=\"1\", text/html;level=2;q=0.4",
+			ctypes:   []string{"text/html;level=2", "text/html;level=1", "text/plain"},
+			dtype:    "text/javascript",
+			modes:    []mimeheader.MatchMode{mimeheader.MatchParamsSubset},
+			expMType: "text/html",
+		},
+		{
+			name:     "Params exact",
+			header:   "text/html;charset=utf-8;q=0.5, text/html;q=0.2",
+			ctypes:   []string{"text/html;charset=UTF-8;level=1", "text/html;charset=UTF8"},
+			dtype:    "text/javascript",
+			modes:    []mimeheader.MatchMode{mimeheader.MatchParamsExact},
+			expMType: "text/html",
+		},
+		{
+			name:     "Suffix",
+			header:   "application/*;q=0.2, APPLICATION/JSON;q=0.5, application/problem+json;q=0.1",
+			ctypes:   []string{"application/problem+json", "application/vnd.acme.order+json", "application/json"},
+			dtype:    "text/javascript",
+			modes:    []mimeheader.MatchMode{mimeheader.MatchSuffix},
+			expMType: "application/json",
+		},
+		{
+			name:     "Suffix has precedence over subtype wildcard",
+			header:   "application/*, application/json;q=0",
+			ctypes:   []string{"application/problem+json", "application/xml"},
+			dtype:    "text/javascript",
+			modes:    []mimeheader.MatchMode{mimeheader.MatchSuffix},
+			expMType: "application/xml",
+		},
+		{
+			name:     "Source quality",
+			header:   "image/*;q=0.8, application/pdf;q=0.5",
+			ctypes:   []string{"image/webp;qs=0.7", "image/png;qs=0.5", "application/pdf"},
+			dtype:    "text/javascript",
+			expMType: "image/webp",
+		},
+		{
+			name:     "Source quality with equal products",
+			header:   "image/png;q=0.5, application/pdf",
+			ctypes:   []string{"image/png", "application/pdf;qs=0.5"},
+			dtype:    "text/javascript",
+			expMType: "application/pdf",
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package mimeheader
+
+// Offer pairs an offered mime type with a value, like an encoder, a template or a handler.
+type Offer[T any] struct {
+	// MimeType is the offered type, it has the same format as common types of AcceptHeader.NegotiateResult.
+	MimeType string
+	Value    T
+}
+
+// Negotiate chooses the offer by the same rules as AcceptHeader.NegotiateResult and returns its value.
+// Zero value, zero result and false are returned if none of offers is acceptable.
+// Modes have the same meaning as for MimeType.Match.
+func Negotiate[T any](ah AcceptHeader, offers []Offer[T], modes ...MatchMode) (value T, res NegotiationResult, matched bool) {
+	ctypes := make([]string, 0, len(offers))
+	for _, offer := range offers {
+		ctypes = append(ctypes, offer.MimeType)
+	}
+
+	res, oid := ah.negotiate(ctypes, mergeModes(modes))
+	if oid < 0 {
+		return value, NegotiationResult{}, false
+	}
+
+	return offers[oid].Value, res, true
+}
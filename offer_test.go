@@ -0,0 +1,106 @@
+package mimeheader_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleNegotiate() {
+	type encoder func(v interface{}) ([]byte, error)
+
+	offers := []mimeheader.Offer[encoder]{
+		{MimeType: "application/json", Value: json.Marshal},
+		{MimeType: "application/xml;qs=0.9", Value: xml.Marshal},
+	}
+
+	ah := mimeheader.ParseAcceptHeader("application/xml, application/*;q=0.5")
+
+	encode, res, matched := mimeheader.Negotiate(ah, offers)
+	if !matched {
+		panic("not acceptable")
+	}
+
+	body, err := encode(struct {
+		XMLName xml.Name `xml:"user"`
+		Name    string   `xml:"name"`
+	}{Name: "Ann"})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(res.MimeType.String(), string(body))
+	// Output:
+	// application/xml <user><name>Ann</name></user>
+}
+
+func TestNegotiate(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerNegotiate() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			ah := mimeheader.ParseAcceptHeader(prov.header)
+
+			value, res, matched := mimeheader.Negotiate(ah, prov.offers, prov.modes...)
+			if value != prov.expValue || res.Offer != prov.expOffer || matched != prov.expMatched {
+				t.Fatalf("Wrong negotiated offer.\nExpected: %d %s %t\nActual: %d %s %t",
+					prov.expValue, prov.expOffer, prov.expMatched, value, res.Offer, matched)
+			}
+		})
+	}
+}
+
+type negotiate struct {
+	name       string
+	header     string
+	offers     []mimeheader.Offer[int]
+	modes      []mimeheader.MatchMode
+	expValue   int
+	expOffer   string
+	expMatched bool
+}
+
+func providerNegotiate() []negotiate {
+	return []negotiate{
+		{
+			name:   "Empty offers",
+			header: "*/*",
+		},
+		{
+			name:       "Not acceptable",
+			header:     "text/*",
+			offers:     []mimeheader.Offer[int]{{MimeType: "application/json", Value: 1}},
+			expMatched: false,
+		},
+		{
+			name:   "Duplicated mime types",
+			header: "text/html;q=0.5, text/*;q=0.1",
+			offers: []mimeheader.Offer[int]{
+				{MimeType: "text/plain", Value: 1},
+				{MimeType: "text/html", Value: 2},
+				{MimeType: "text/html", Value: 3},
+			},
+			expValue:   2,
+			expOffer:   "text/html",
+			expMatched: true,
+		},
+		{
+			name:   "Suffix",
+			header: "application/json",
+			offers: []mimeheader.Offer[int]{
+				{MimeType: "application/", Value: 1},
+				{MimeType: "application/problem+json", Value: 2},
+			},
+			modes:      []mimeheader.MatchMode{mimeheader.MatchSuffix},
+			expValue:   2,
+			expOffer:   "application/problem+json",
+			expMatched: true,
+		},
+	}
+}
@@ -7,9 +7,15 @@ import (
 
 // Error messages.
 const (
-	MimeParseErrMsg        = "error in a parse media type"
-	MimeTypePartsErrMsg    = "wrong number of mime type parts"
-	MimeTypeWildcardErrMsg = "mimetype cannot be as */plain"
+	MimeParseErrMsg           = "error in a parse media type"
+	MimeTypePartsErrMsg       = "wrong number of mime type parts"
+	MimeTypeWildcardErrMsg    = "mimetype cannot be as */plain"
+	ContentTypeWildcardErrMsg = "content type cannot be a wildcard"
+
+	AcceptBadTokenMsg       = "invalid token in media range"
+	AcceptBadQualityMsg     = "invalid qvalue in media range"
+	AcceptDuplicateParamMsg = "duplicate parameter in media range"
+	AcceptWildcardMsg       = "media range cannot be as */plain"
 )
 
 // ParseMediaType parses media type to MimeType structure.
@@ -37,3 +43,45 @@ func ParseMediaType(mtype string) (MimeType, error) {
 
 	return mt, nil
 }
+
+// parseContentType parses Content-Type header value.
+// Content-Type MUST be a specific type, wildcards are allowed only in ranges, MimeTypeWildcardErr is returned for them.
+func parseContentType(ctype string) (MimeType, error) {
+	mtype, err := ParseMediaType(ctype)
+	if err != nil {
+		return MimeType{}, err
+	}
+
+	if mtype.Type == MimeAny || mtype.Subtype == MimeAny {
+		return MimeType{}, MimeTypeWildcardErr{Msg: ContentTypeWildcardErrMsg}
+	}
+
+	return mtype, nil
+}
+
+// tokenSpecials are non alphanumeric characters allowed in a token (RFC 9110 Sec 5.6.2).
+const tokenSpecials = "!#$%&'*+-.^_`|~"
+
+// isToken reports whether the value is a non-empty token (RFC 9110 Sec 5.6.2).
+func isToken(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for i := 0; i < len(value); i++ {
+		if !isTokenChar(value[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isTokenChar reports whether the character is allowed in a token (tchar).
+func isTokenChar(c byte) bool {
+	if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') {
+		return true
+	}
+
+	return strings.IndexByte(tokenSpecials, c) >= 0
+}
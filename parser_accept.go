@@ -7,39 +7,119 @@ import (
 
 const DefaultQuality float32 = 1.0
 
-func ParseAcceptHeader(header string) AcceptHeader {
-	accepts := strings.Split(header, ",")
-	if len(accepts) == 0 {
-		return AcceptHeader{}
-	}
+// Separators of list based headers, like Accept, Accept-Language, etc.
+const (
+	ListSeparator  = ","
+	ParamSeparator = ";"
+	ParamAssign    = "="
+)
+
+// QualityParam is a name of the weight parameter.
+const QualityParam = "q"
+
+// SourceQualityParam is a name of the server-side source quality parameter of an offered type, like "image/png;qs=0.7".
+const SourceQualityParam = "qs"
 
-	mheaders := make([]MimeHeader, 0, len(accepts))
+// SourceQualityErrMsg is an error message for invalid source quality of an offered type.
+const SourceQualityErrMsg = "invalid source quality"
 
-	for _, accept := range accepts {
-		mtype, err := ParseMediaType(accept)
-		if err != nil {
+// ParseAcceptHeader parses Accept header to AcceptHeader structure.
+// The header is scanned in a single pass with respect to quoted strings, invalid ranges are skipped.
+// Params are materialized only for valid ranges, ranges are sorted by precedence.
+func ParseAcceptHeader(header string) AcceptHeader {
+	mheaders := make([]MimeHeader, 0, strings.Count(header, ListSeparator)+1)
+	scanner := newRangeScanner(header)
+
+	for sr, ok := scanner.next(); ok; sr, ok = scanner.next() {
+		if sr.reason != scanOK {
 			continue
 		}
 
-		header := MimeHeader{
-			MimeType: mtype,
-			Quality:  DefaultQuality,
+		mheader := sr.mimeHeader()
+		if mheader.Params == nil {
+			mheader.Params = map[string]string{}
+		}
+
+		mheaders = append(mheaders, mheader)
+	}
+
+	ah := AcceptHeader{MHeaders: mheaders}
+	ah.sort()
+
+	return ah
+}
+
+// weighted is a value from a comma separated list with its weight, like "gzip;q=0.8" or "en-US;q=0.5".
+type weighted struct {
+	value   string
+	quality float32
+}
+
+// parseWeightedList parses comma separated list of values with optional weights.
+// Empty values are skipped, all params except of the weight are ignored.
+func parseWeightedList(header string) []weighted {
+	elements := strings.Split(header, ListSeparator)
+	values := make([]weighted, 0, len(elements))
+
+	for _, element := range elements {
+		parts := strings.Split(element, ParamSeparator)
+
+		value := strings.TrimSpace(parts[0])
+		if value == "" {
+			continue
 		}
 
-		if qs, ok := header.Params["q"]; ok {
-			const floatSize = 32
-			quality, err := strconv.ParseFloat(qs, floatSize)
+		w := weighted{value: value, quality: DefaultQuality}
 
-			if err == nil {
-				header.Quality = float32(quality)
+		for _, param := range parts[1:] {
+			name, qs, ok := cutParam(param)
+			if ok && strings.EqualFold(name, QualityParam) {
+				w.quality = parseQuality(qs)
 			}
 		}
 
-		mheaders = append(mheaders, header)
+		values = append(values, w)
 	}
 
-	ah := AcceptHeader{}
-	ah.Set(mheaders)
+	return values
+}
 
-	return ah
+// cutParam splits parameter to name and value, like "q=0.5".
+func cutParam(param string) (name, value string, ok bool) {
+	idx := strings.Index(param, ParamAssign)
+	if idx < 0 {
+		return "", "", false
+	}
+
+	return strings.TrimSpace(param[:idx]), strings.TrimSpace(param[idx+1:]), true
+}
+
+// cutSourceQuality removes SourceQualityParam from params of the offered type and returns its value.
+// DefaultQuality is returned if the param is missed. The value MUST match qvalue grammar, like quality of a range,
+// zero quality and SourceQualityErr are returned for other values, like "qs=5", "qs=-1" or "qs=abc".
+func cutSourceQuality(mtype MimeType) (float32, error) {
+	qs, ok := mtype.Params[SourceQualityParam]
+	if !ok {
+		return DefaultQuality, nil
+	}
+
+	delete(mtype.Params, SourceQualityParam)
+
+	if !validQuality(qs) {
+		return 0, SourceQualityErr{Msg: SourceQualityErrMsg, Value: qs}
+	}
+
+	return parseQuality(qs), nil
+}
+
+// parseQuality parses weight value. DefaultQuality is returned for the broken value.
+func parseQuality(qs string) float32 {
+	const floatSize = 32
+
+	quality, err := strconv.ParseFloat(qs, floatSize)
+	if err != nil {
+		return DefaultQuality
+	}
+
+	return float32(quality)
 }
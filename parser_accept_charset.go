@@ -0,0 +1,17 @@
+package mimeheader
+
+// ParseAcceptCharset parses Accept-Charset header to AcceptCharset structure.
+// Invalid charsets are skipped, charsets are sorted by precedence.
+func ParseAcceptCharset(header string) AcceptCharset {
+	values := parseWeightedList(header)
+	ranges := make([]CharsetRange, 0, len(values))
+
+	for _, value := range values {
+		ranges = append(ranges, CharsetRange{Charset: value.value, Quality: value.quality})
+	}
+
+	ac := AcceptCharset{}
+	ac.Set(ranges)
+
+	return ac
+}
@@ -0,0 +1,68 @@
+package mimeheader_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleParseAcceptCharset() {
+	ac := mimeheader.ParseAcceptCharset("iso-8859-5, unicode-1-1;q=0.8, *;q=0.1")
+
+	fmt.Println(ac.Negotiate([]string{"utf-8", "ISO-8859-5"}, "utf-8"))
+	fmt.Println(ac.Match("latin1"))
+	// Output:
+	// {iso-8859-5 1} ISO-8859-5 true
+	// true
+}
+
+func TestParseAcceptCharset(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerParseAcceptCharset() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			act := mimeheader.ParseAcceptCharset(prov.header)
+			if !reflect.DeepEqual(prov.exp, act) {
+				t.Fatalf("AcceptCharsets are not equal.\nExpected: %+v\nActual: %+v", prov.exp, act)
+			}
+		})
+	}
+}
+
+type parseAcceptCharset struct {
+	name   string
+	header string
+	exp    mimeheader.AcceptCharset
+}
+
+func providerParseAcceptCharset() []parseAcceptCharset {
+	return []parseAcceptCharset{
+		{
+			name:   "Empty",
+			header: "",
+			exp:    mimeheader.NewAcceptCharsetPlain([]mimeheader.CharsetRange{}),
+		},
+		{
+			name:   "Sorted by quality with stable order",
+			header: "*;q=0.5, utf-8, latin1;q=0.7, windows-1252;q=0.7",
+			exp: mimeheader.NewAcceptCharsetPlain([]mimeheader.CharsetRange{
+				{Charset: "utf-8", Quality: 1},
+				{Charset: "latin1", Quality: 0.7},
+				{Charset: "windows-1252", Quality: 0.7},
+				{Charset: "*", Quality: 0.5},
+			}),
+		},
+		{
+			name:   "Invalid charsets",
+			header: "utf 8, \"utf-8\", , UTF-16 ; q=0.5",
+			exp: mimeheader.NewAcceptCharsetPlain([]mimeheader.CharsetRange{
+				{Charset: "UTF-16", Quality: 0.5},
+			}),
+		},
+	}
+}
@@ -0,0 +1,17 @@
+package mimeheader
+
+// ParseAcceptEncoding parses Accept-Encoding header to AcceptEncoding structure.
+// Invalid codings are skipped, codings are sorted by precedence.
+func ParseAcceptEncoding(header string) AcceptEncoding {
+	values := parseWeightedList(header)
+	ranges := make([]EncodingRange, 0, len(values))
+
+	for _, value := range values {
+		ranges = append(ranges, EncodingRange{Coding: value.value, Quality: value.quality})
+	}
+
+	ae := AcceptEncoding{}
+	ae.Set(ranges)
+
+	return ae
+}
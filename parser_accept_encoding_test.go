@@ -0,0 +1,79 @@
+package mimeheader_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleParseAcceptEncoding() {
+	ae := mimeheader.ParseAcceptEncoding("deflate, gzip;q=1.0, *;q=0.5")
+
+	fmt.Println(ae.Negotiate([]string{"br", "gzip"}))
+	fmt.Println(ae.Negotiate([]string{"br", "zstd"}))
+	fmt.Println(ae.Match("identity"))
+	// Output:
+	// {gzip 1} gzip true
+	// {* 0.5} br true
+	// true
+}
+
+func TestParseAcceptEncoding(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerParseAcceptEncoding() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			act := mimeheader.ParseAcceptEncoding(prov.header)
+			if !reflect.DeepEqual(prov.exp, act) {
+				t.Fatalf("AcceptEncodings are not equal.\nExpected: %+v\nActual: %+v", prov.exp, act)
+			}
+		})
+	}
+}
+
+type parseAcceptEncoding struct {
+	name   string
+	header string
+	exp    mimeheader.AcceptEncoding
+}
+
+func providerParseAcceptEncoding() []parseAcceptEncoding {
+	return []parseAcceptEncoding{
+		{
+			name:   "Empty",
+			header: "",
+			exp:    mimeheader.NewAcceptEncodingPlain([]mimeheader.EncodingRange{}),
+		},
+		{
+			name:   "Sorted by quality with stable order",
+			header: "*;q=0.1, br;q=0.8, gzip, deflate;q=0.8, identity",
+			exp: mimeheader.NewAcceptEncodingPlain([]mimeheader.EncodingRange{
+				{Coding: "gzip", Quality: 1},
+				{Coding: "identity", Quality: 1},
+				{Coding: "br", Quality: 0.8},
+				{Coding: "deflate", Quality: 0.8},
+				{Coding: "*", Quality: 0.1},
+			}),
+		},
+		{
+			name:   "Wildcard has less priority",
+			header: "*, zstd",
+			exp: mimeheader.NewAcceptEncodingPlain([]mimeheader.EncodingRange{
+				{Coding: "zstd", Quality: 1},
+				{Coding: "*", Quality: 1},
+			}),
+		},
+		{
+			name:   "Invalid codings",
+			header: "g zip, [br], , gzip ; q=0.5",
+			exp: mimeheader.NewAcceptEncodingPlain([]mimeheader.EncodingRange{
+				{Coding: "gzip", Quality: 0.5},
+			}),
+		},
+	}
+}
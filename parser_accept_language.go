@@ -0,0 +1,17 @@
+package mimeheader
+
+// ParseAcceptLanguage parses Accept-Language header to AcceptLanguage structure.
+// Invalid language ranges are skipped, ranges are sorted by precedence.
+func ParseAcceptLanguage(header string) AcceptLanguage {
+	values := parseWeightedList(header)
+	ranges := make([]LanguageRange, 0, len(values))
+
+	for _, value := range values {
+		ranges = append(ranges, LanguageRange{Tag: value.value, Quality: value.quality})
+	}
+
+	al := AcceptLanguage{}
+	al.Set(ranges)
+
+	return al
+}
@@ -0,0 +1,95 @@
+package mimeheader_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleParseAcceptLanguage() {
+	al := mimeheader.ParseAcceptLanguage("fr-CH, fr;q=0.9, en;q=0.8, de;q=0.7, *;q=0.5")
+
+	fmt.Println(al.Negotiate([]string{"en-US", "de"}, "uk"))
+	fmt.Println(al.Negotiate([]string{"it"}, "uk"))
+	fmt.Println(al.Match("fr"))
+	// Output:
+	// {en 0.8} en-US true
+	// {* 0.5} it true
+	// true
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerParseAcceptLanguage() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			act := mimeheader.ParseAcceptLanguage(prov.header)
+			if !reflect.DeepEqual(prov.exp, act) {
+				t.Fatalf("AcceptLanguages are not equal.\nExpected: %+v\nActual: %+v", prov.exp, act)
+			}
+		})
+	}
+}
+
+type parseAcceptLanguage struct {
+	name   string
+	header string
+	exp    mimeheader.AcceptLanguage
+}
+
+func providerParseAcceptLanguage() []parseAcceptLanguage {
+	return []parseAcceptLanguage{
+		{
+			name:   "Empty",
+			header: "",
+			exp:    mimeheader.NewAcceptLanguagePlain([]mimeheader.LanguageRange{}),
+		},
+		{
+			name:   "Wildcard",
+			header: "*",
+			exp:    mimeheader.NewAcceptLanguagePlain([]mimeheader.LanguageRange{{Tag: "*", Quality: 1}}),
+		},
+		{
+			name:   "Sorted by quality with stable order",
+			header: "de;q=0.7, fr-CH, en;q=0.8, fr;q=0.9, it;q=0.7",
+			exp: mimeheader.NewAcceptLanguagePlain([]mimeheader.LanguageRange{
+				{Tag: "fr-CH", Quality: 1},
+				{Tag: "fr", Quality: 0.9},
+				{Tag: "en", Quality: 0.8},
+				{Tag: "de", Quality: 0.7},
+				{Tag: "it", Quality: 0.7},
+			}),
+		},
+		{
+			name:   "Sorted by specificity",
+			header: "*, en, zh-Hant-TW, zh-Hant",
+			exp: mimeheader.NewAcceptLanguagePlain([]mimeheader.LanguageRange{
+				{Tag: "zh-Hant-TW", Quality: 1},
+				{Tag: "zh-Hant", Quality: 1},
+				{Tag: "en", Quality: 1},
+				{Tag: "*", Quality: 1},
+			}),
+		},
+		{
+			name:   "Spaces and broken quality",
+			header: " en-GB ; q = 0.5 ,, uk ; q=wrong, ",
+			exp: mimeheader.NewAcceptLanguagePlain([]mimeheader.LanguageRange{
+				{Tag: "uk", Quality: 1},
+				{Tag: "en-GB", Quality: 0.5},
+			}),
+		},
+		{
+			name:   "Invalid ranges",
+			header: "en_US, 1en, en--US, toolongtag, en-*, de-1996, sgn-CH-DE",
+			exp: mimeheader.NewAcceptLanguagePlain([]mimeheader.LanguageRange{
+				{Tag: "sgn-CH-DE", Quality: 1},
+				{Tag: "de-1996", Quality: 1},
+			}),
+		},
+	}
+}
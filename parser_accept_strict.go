@@ -0,0 +1,76 @@
+package mimeheader
+
+// Limits of qvalue grammar (RFC 9110 Sec 12.4.2).
+const qvalueMaxDigits = 3
+
+// ParseAcceptHeaderStrict parses Accept header strictly by RFC 9110 grammar (Sec 12.5.1).
+// Unlike ParseAcceptHeader, ranges with invalid qvalue, like "q=7", "q=-1" or "q=0.12345", are rejected
+// and whitespaces around '=' in parameters are not allowed.
+// Every rejected range is reported by AcceptDiagnostic, valid ranges are returned in AcceptHeader.
+func ParseAcceptHeaderStrict(header string) (AcceptHeader, []AcceptDiagnostic) {
+	var diagnostics []AcceptDiagnostic
+
+	mheaders := make([]MimeHeader, 0)
+	scanner := newRangeScanner(header)
+	scanner.strict = true
+
+	for idx := 0; ; idx++ {
+		sr, ok := scanner.next()
+		if !ok {
+			break
+		}
+
+		diagnostic := AcceptDiagnostic{Index: idx, Offset: sr.errOffset, Range: header[sr.offset:sr.end]}
+
+		switch {
+		case sr.reason == scanDuplicateParam:
+			diagnostic.Reason = AcceptDuplicateParam
+		case sr.reason == scanWildcard:
+			diagnostic.Reason = AcceptWildcard
+		case sr.reason != scanOK:
+			diagnostic.Reason = AcceptBadToken
+		case sr.qvalue != "" && !validQuality(sr.qvalue):
+			diagnostic.Reason = AcceptBadQuality
+			diagnostic.Offset = sr.qoffset
+		default:
+			mheader := sr.mimeHeader()
+			if mheader.Params == nil {
+				mheader.Params = map[string]string{}
+			}
+
+			mheaders = append(mheaders, mheader)
+
+			continue
+		}
+
+		diagnostics = append(diagnostics, diagnostic)
+	}
+
+	ah := AcceptHeader{MHeaders: mheaders}
+	ah.sort()
+
+	return ah, diagnostics
+}
+
+// validQuality validates qvalue = ( "0" [ "." 0*3DIGIT ] ) / ( "1" [ "." 0*3("0") ] ).
+func validQuality(qs string) bool {
+	if qs == "" || (qs[0] != '0' && qs[0] != '1') {
+		return false
+	}
+
+	if len(qs) == 1 {
+		return true
+	}
+
+	if qs[1] != '.' || len(qs) > qvalueMaxDigits+2 {
+		return false
+	}
+
+	for i := 2; i < len(qs); i++ {
+		if qs[i] < '0' || qs[i] > '9' || (qs[0] == '1' && qs[i] != '0') {
+			return false
+		}
+	}
+
+	return true
+}
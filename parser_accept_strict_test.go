@@ -0,0 +1,119 @@
+package mimeheader_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func ExampleParseAcceptHeaderStrict() {
+	ah, diagnostics := mimeheader.ParseAcceptHeaderStrict("text/html;q=7, application/json, */plain")
+
+	fmt.Println(ah.Match("application/json"), ah.Match("text/html"))
+
+	for _, diagnostic := range diagnostics {
+		fmt.Println(diagnostic)
+	}
+	// Output:
+	// true false
+	// invalid qvalue in media range #0 at offset 12: "text/html;q=7"
+	// media range cannot be as */plain #2 at offset 33: "*/plain"
+}
+
+func TestParseAcceptHeaderStrict(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerParseAcceptHeaderStrict() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			act, actDiagnostics := mimeheader.ParseAcceptHeaderStrict(prov.header)
+			if !reflect.DeepEqual(prov.exp, act) {
+				t.Errorf("AcceptHeaders are not equal.\nExpected: %+v\nActual: %+v", prov.exp, act)
+			}
+
+			if !reflect.DeepEqual(prov.expDiagnostics, actDiagnostics) {
+				t.Errorf("Diagnostics are not equal.\nExpected: %+v\nActual: %+v", prov.expDiagnostics, actDiagnostics)
+			}
+		})
+	}
+}
+
+type parseAcceptHeaderStrict struct {
+	name           string
+	header         string
+	exp            mimeheader.AcceptHeader
+	expDiagnostics []mimeheader.AcceptDiagnostic
+}
+
+func providerParseAcceptHeaderStrict() []parseAcceptHeaderStrict {
+	return []parseAcceptHeaderStrict{
+		{
+			name:   "Empty",
+			header: "",
+			exp:    mimeheader.NewAcceptHeaderPlain([]mimeheader.MimeHeader{}),
+		},
+		{
+			name:   "Valid header with empty elements",
+			header: " , text/html;level=1;q=0.5 ,,application/json;q=1.000",
+			exp: mimeheader.NewAcceptHeaderPlain([]mimeheader.MimeHeader{
+				{
+					MimeType: mimeheader.MimeType{Type: "application", Subtype: "json", Params: map[string]string{}},
+					Quality:  1,
+				},
+				{
+					MimeType: mimeheader.MimeType{Type: "text", Subtype: "html", Params: map[string]string{"level": "1"}},
+					Quality:  0.5,
+				},
+			}),
+		},
+		{
+			name:   "Invalid qvalues",
+			header: "a/b;q=7, a/c;q=-1, a/d;q=0.12345, a/e;q=1.001, a/f;q=.5, a/g;q=\"0.5\", a/h;q=0.",
+			exp: mimeheader.NewAcceptHeaderPlain([]mimeheader.MimeHeader{
+				{
+					MimeType: mimeheader.MimeType{Type: "a", Subtype: "h", Params: map[string]string{}},
+					Quality:  0,
+				},
+			}),
+			expDiagnostics: []mimeheader.AcceptDiagnostic{
+				{Index: 0, Offset: 6, Reason: mimeheader.AcceptBadQuality, Range: "a/b;q=7"},
+				{Index: 1, Offset: 15, Reason: mimeheader.AcceptBadQuality, Range: "a/c;q=-1"},
+				{Index: 2, Offset: 25, Reason: mimeheader.AcceptBadQuality, Range: "a/d;q=0.12345"},
+				{Index: 3, Offset: 40, Reason: mimeheader.AcceptBadQuality, Range: "a/e;q=1.001"},
+				{Index: 4, Offset: 53, Reason: mimeheader.AcceptBadQuality, Range: "a/f;q=.5"},
+				{Index: 5, Offset: 63, Reason: mimeheader.AcceptBadQuality, Range: "a/g;q=\"0.5\""},
+			},
+		},
+		{
+			name:   "Bad tokens",
+			header: "text/html;a = 1, text/, {}/json, text/html;;, text/html;a=\"b, text/html x",
+			exp:    mimeheader.NewAcceptHeaderPlain([]mimeheader.MimeHeader{}),
+			expDiagnostics: []mimeheader.AcceptDiagnostic{
+				{Index: 0, Offset: 11, Reason: mimeheader.AcceptBadToken, Range: "text/html;a = 1"},
+				{Index: 1, Offset: 22, Reason: mimeheader.AcceptBadToken, Range: "text/"},
+				{Index: 2, Offset: 24, Reason: mimeheader.AcceptBadToken, Range: "{}/json"},
+				{Index: 3, Offset: 43, Reason: mimeheader.AcceptBadToken, Range: "text/html;;"},
+				{Index: 4, Offset: 58, Reason: mimeheader.AcceptBadToken, Range: "text/html;a=\"b, text/html x"},
+			},
+		},
+		{
+			name:   "Duplicated params and wildcard",
+			header: "text/html;level=1;LEVEL=2, text/html;q=1;q=0.5, */html, text/plain",
+			exp: mimeheader.NewAcceptHeaderPlain([]mimeheader.MimeHeader{
+				{
+					MimeType: mimeheader.MimeType{Type: "text", Subtype: "plain", Params: map[string]string{}},
+					Quality:  1,
+				},
+			}),
+			expDiagnostics: []mimeheader.AcceptDiagnostic{
+				{Index: 0, Offset: 18, Reason: mimeheader.AcceptDuplicateParam, Range: "text/html;level=1;LEVEL=2"},
+				{Index: 1, Offset: 41, Reason: mimeheader.AcceptDuplicateParam, Range: "text/html;q=1;q=0.5"},
+				{Index: 2, Offset: 48, Reason: mimeheader.AcceptWildcard, Range: "*/html"},
+			},
+		},
+	}
+}
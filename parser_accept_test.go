@@ -2,7 +2,10 @@ package mimeheader_test
 
 import (
 	"fmt"
+	"mime"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/aohorodnyk/mimeheader"
@@ -22,7 +25,7 @@ func ExampleParseAcceptHeader() {
 	// Output:
 	// image/* image/tiff true
 	// image/png image/png true
-	// image/* image/svg true
+	// image/svg image/svg true
 	//  text/javascript false
 	// true
 	// false
@@ -44,6 +47,72 @@ func TestParseAcceptHeader(t *testing.T) {
 	}
 }
 
+// TestParseAcceptHeader_mime compares results with the parser based on mime.ParseMediaType for valid headers.
+func TestParseAcceptHeader_mime(t *testing.T) {
+	t.Parallel()
+
+	headers := []string{
+		"text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8",
+		"TEXT/*; Q=0.9; Level=1, application/json ; q = 0.5 ; charset=UTF-8",
+		`application/vnd.api+json; ext="https://example.com/ext"; profile="a b"`,
+		"*/*; q=0.9; s=1, image/*; q=0.9; s=4, application/json; q=0.9; b=3;, text/plain",
+	}
+
+	for _, header := range headers {
+		header := header
+		t.Run(header, func(t *testing.T) {
+			t.Parallel()
+
+			mheaders := []mimeheader.MimeHeader{}
+
+			for _, accept := range strings.Split(header, ",") {
+				// Params after the weight are accept extensions.
+				segments := strings.Split(accept, ";")
+				quality, qid := 1.0, len(segments)
+
+				for sid, segment := range segments {
+					param := strings.SplitN(segment, "=", 2)
+					if len(param) == 2 && strings.EqualFold(strings.TrimSpace(param[0]), "q") {
+						quality, _ = strconv.ParseFloat(strings.TrimSpace(param[1]), 32)
+						qid = sid
+					}
+				}
+
+				mtype, params, err := mime.ParseMediaType(strings.Join(segments[:qid], ";"))
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+
+				mparts := strings.Split(mtype, "/")
+				mheader := mimeheader.MimeHeader{
+					MimeType: mimeheader.MimeType{Type: mparts[0], Subtype: mparts[1], Params: params},
+					Quality:  float32(quality),
+				}
+
+				if qid+1 < len(segments) {
+					_, mheader.Extensions, err = mime.ParseMediaType("ext/ext;" + strings.Join(segments[qid+1:], ";"))
+					if err != nil {
+						t.Fatalf("Unexpected error: %v", err)
+					}
+
+					if len(mheader.Extensions) == 0 {
+						mheader.Extensions = nil
+					}
+				}
+
+				mheaders = append(mheaders, mheader)
+			}
+
+			exp := mimeheader.NewAcceptHeader(mheaders)
+			act := mimeheader.ParseAcceptHeader(header)
+
+			if !reflect.DeepEqual(exp, act) {
+				t.Fatalf("AcceptHeaders are not equal.\nExpected: %+v\nActual: %+v", exp, act)
+			}
+		})
+	}
+}
+
 type parseAcceptHeader struct {
 	name   string
 	header string
@@ -92,25 +161,28 @@ func providerParseAcceptHeader() []parseAcceptHeader {
 					MimeType: mimeheader.MimeType{
 						Type:    "application",
 						Subtype: "json",
-						Params:  map[string]string{"q": "0.9", "b": "3"},
+						Params:  map[string]string{},
 					},
-					Quality: 0.9,
+					Quality:    0.9,
+					Extensions: map[string]string{"b": "3"},
 				},
 				{
 					MimeType: mimeheader.MimeType{
 						Type:    "image",
 						Subtype: "*",
-						Params:  map[string]string{"q": "0.9", "s": "4"},
+						Params:  map[string]string{},
 					},
-					Quality: 0.9,
+					Quality:    0.9,
+					Extensions: map[string]string{"s": "4"},
 				},
 				{
 					MimeType: mimeheader.MimeType{
 						Type:    "*",
 						Subtype: "*",
-						Params:  map[string]string{"q": "0.9", "s": "1"},
+						Params:  map[string]string{},
 					},
-					Quality: 0.9,
+					Quality:    0.9,
+					Extensions: map[string]string{"s": "1"},
 				},
 			}),
 		},
@@ -130,17 +202,61 @@ func providerParseAcceptHeader() []parseAcceptHeader {
 					MimeType: mimeheader.MimeType{
 						Type:    "application",
 						Subtype: "json",
-						Params:  map[string]string{"q": "0.9", "b": "3"},
+						Params:  map[string]string{},
 					},
-					Quality: 0.9,
+					Quality:    0.9,
+					Extensions: map[string]string{"b": "3"},
 				},
 				{
 					MimeType: mimeheader.MimeType{
 						Type:    "image",
 						Subtype: "*",
-						Params:  map[string]string{"q": "0.9", "s": "4"},
+						Params:  map[string]string{},
+					},
+					Quality:    0.9,
+					Extensions: map[string]string{"s": "4"},
+				},
+			}),
+		},
+		{
+			name:   "Quoted params with separators",
+			header: `text/html;foo="a,b;c=d", application/json;q=0.5;title="say \"hi\", \\o/"`,
+			exp: mimeheader.NewAcceptHeaderPlain([]mimeheader.MimeHeader{
+				{
+					MimeType: mimeheader.MimeType{
+						Type:    "text",
+						Subtype: "html",
+						Params:  map[string]string{"foo": "a,b;c=d"},
+					},
+					Quality: 1.0,
+				},
+				{
+					MimeType: mimeheader.MimeType{
+						Type:    "application",
+						Subtype: "json",
+						Params:  map[string]string{},
+					},
+					Quality:    0.5,
+					Extensions: map[string]string{"title": `say "hi", \o/`},
+				},
+			}),
+		},
+		{
+			name:   "Invalid ranges with quoted separators are skipped entirely",
+			header: `text/html foo="a,b", text/plain;a="unterminated, image/png`,
+			exp:    mimeheader.NewAcceptHeaderPlain([]mimeheader.MimeHeader{}),
+		},
+		{
+			name:   "Duplicated params and wrong delimiters",
+			header: "text/html;a=1;A=2, text/plain;;, image/png;q=0.1;, */plain, text/",
+			exp: mimeheader.NewAcceptHeaderPlain([]mimeheader.MimeHeader{
+				{
+					MimeType: mimeheader.MimeType{
+						Type:    "image",
+						Subtype: "png",
+						Params:  map[string]string{},
 					},
-					Quality: 0.9,
+					Quality: 0.1,
 				},
 			}),
 		},
@@ -0,0 +1,422 @@
+package mimeheader
+
+import "strings"
+
+// scanReason describes why a media range was rejected by rangeScanner.
+type scanReason int
+
+const (
+	scanOK scanReason = iota
+	scanBadType
+	scanBadSubtype
+	scanBadParam
+	scanDuplicateParam
+	scanWildcard
+)
+
+// scannedRange is a media range found by rangeScanner. All strings reference the scanned header.
+type scannedRange struct {
+	typ     string
+	subtype string
+	// params contains raw media type parameters of the range placed before quality, it's empty or starts with ';'.
+	params string
+	// exts contains raw accept extensions placed after quality, it's empty or starts with ';'.
+	exts string
+	// nparams is a number of media type parameters.
+	nparams int
+	// qvalue is a raw value of quality parameter, it's empty if the parameter is missed.
+	qvalue  string
+	quality float32
+	// qstart and qend are offsets of quality parameter in the header.
+	qstart int
+	qend   int
+	// offset and end of the range in the header.
+	offset int
+	end    int
+	// qoffset is an offset of quality value in the header.
+	qoffset int
+	// reason is scanOK for a valid range, errOffset points to the invalid part of a header otherwise.
+	reason    scanReason
+	errOffset int
+	// suffix reports whether the range matched an offered type by the structured syntax suffix.
+	suffix bool
+}
+
+// rangeScanner iterates over media ranges of Accept header in a single pass without allocations.
+// Quoted strings of parameters are respected, so separators inside of them do not break ranges.
+type rangeScanner struct {
+	header string
+	pos    int
+	// strict disallows whitespaces around '=' in parameters.
+	strict bool
+}
+
+func newRangeScanner(header string) rangeScanner {
+	return rangeScanner{header: header}
+}
+
+// next returns the next non-empty element of the header. Invalid ranges are returned with a reason.
+// The header is fully scanned when the second parameter is false.
+func (s *rangeScanner) next() (scannedRange, bool) {
+	for s.pos < len(s.header) && (isOWS(s.header[s.pos]) || s.header[s.pos] == ',') {
+		s.pos++
+	}
+
+	if s.pos >= len(s.header) {
+		return scannedRange{}, false
+	}
+
+	sr := s.scan()
+	if sr.reason != scanOK {
+		s.skipElement()
+	}
+
+	sr.end = s.pos
+	for sr.end > sr.offset && isOWS(s.header[sr.end-1]) {
+		sr.end--
+	}
+
+	return sr, true
+}
+
+// scan parses the media range from the current position.
+func (s *rangeScanner) scan() scannedRange {
+	sr := scannedRange{offset: s.pos, quality: DefaultQuality}
+
+	sr.typ = s.token()
+	if sr.typ == "" || s.pos >= len(s.header) || s.header[s.pos] != '/' {
+		return s.fail(sr, scanBadType)
+	}
+
+	s.pos++
+
+	sr.subtype = s.token()
+	if sr.subtype == "" {
+		return s.fail(sr, scanBadSubtype)
+	}
+
+	if sr.typ == MimeAny && sr.subtype != MimeAny {
+		sr.reason, sr.errOffset = scanWildcard, sr.offset
+
+		return sr
+	}
+
+	start := s.pos
+
+	for {
+		s.skipOWS()
+
+		if s.pos >= len(s.header) || s.header[s.pos] == ',' {
+			sr.params = strings.TrimRight(s.header[start:s.pos], " \t")
+
+			if sr.qvalue != "" {
+				sr.params = s.header[start:sr.qstart]
+				sr.exts = strings.TrimRight(s.header[sr.qend:s.pos], " \t")
+			}
+
+			return sr
+		}
+
+		if s.header[s.pos] != ';' {
+			return s.fail(sr, scanBadParam)
+		}
+
+		semicolon := s.pos
+		s.pos++
+		s.skipOWS()
+
+		// A single trailing semicolon is allowed.
+		if s.pos >= len(s.header) || s.header[s.pos] == ',' {
+			continue
+		}
+
+		if reason := s.param(&sr, start, semicolon); reason != scanOK {
+			return s.fail(sr, reason)
+		}
+	}
+}
+
+// param parses a single parameter and stores its data to the scanned range.
+// Parameters before quality are media type parameters and after quality are accept extensions.
+func (s *rangeScanner) param(sr *scannedRange, start, semicolon int) scanReason {
+	nameOffset := s.pos
+
+	name := s.token()
+	if !s.strict {
+		s.skipOWS()
+	}
+
+	if name == "" || s.pos >= len(s.header) || s.header[s.pos] != '=' {
+		return scanBadParam
+	}
+
+	s.pos++
+
+	if !s.strict {
+		s.skipOWS()
+	}
+
+	valueOffset := s.pos
+
+	value, ok := s.value()
+	if !ok {
+		return scanBadParam
+	}
+
+	ps := paramScanner{params: s.header[start:nameOffset]}
+	for pname, _, ok := ps.next(); ok; pname, _, ok = ps.next() {
+		if strings.EqualFold(pname, name) {
+			s.pos = nameOffset
+
+			return scanDuplicateParam
+		}
+	}
+
+	switch {
+	case strings.EqualFold(name, QualityParam):
+		sr.qvalue = value
+		sr.qoffset = valueOffset
+		sr.quality = parseQuality(value)
+		sr.qstart = semicolon
+		sr.qend = s.pos
+	case sr.qvalue == "":
+		sr.nparams++
+	}
+
+	return scanOK
+}
+
+// value parses a token or a quoted string. Quoted string is returned with quotes.
+func (s *rangeScanner) value() (string, bool) {
+	if s.pos >= len(s.header) || s.header[s.pos] != '"' {
+		value := s.token()
+
+		return value, value != ""
+	}
+
+	end, ok := quotedStringEnd(s.header, s.pos)
+	if !ok {
+		return "", false
+	}
+
+	value := s.header[s.pos:end]
+	s.pos = end
+
+	return value, true
+}
+
+func (s *rangeScanner) token() string {
+	start := s.pos
+	for s.pos < len(s.header) && isTokenChar(s.header[s.pos]) {
+		s.pos++
+	}
+
+	return s.header[start:s.pos]
+}
+
+func (s *rangeScanner) skipOWS() {
+	for s.pos < len(s.header) && isOWS(s.header[s.pos]) {
+		s.pos++
+	}
+}
+
+// skipElement moves position to the end of the current list element, quoted strings are skipped entirely.
+func (s *rangeScanner) skipElement() {
+	for s.pos < len(s.header) && s.header[s.pos] != ',' {
+		if s.header[s.pos] != '"' {
+			s.pos++
+
+			continue
+		}
+
+		end, ok := quotedStringEnd(s.header, s.pos)
+		if !ok {
+			s.pos = len(s.header)
+
+			return
+		}
+
+		s.pos = end
+	}
+}
+
+func (s *rangeScanner) fail(sr scannedRange, reason scanReason) scannedRange {
+	sr.reason = reason
+	sr.errOffset = s.pos
+
+	return sr
+}
+
+// paramScanner iterates over parameters already validated by rangeScanner.
+type paramScanner struct {
+	params string
+	pos    int
+}
+
+// next returns the next parameter, quoted values are returned with quotes.
+func (s *paramScanner) next() (name, value string, ok bool) {
+	for s.pos < len(s.params) && (isOWS(s.params[s.pos]) || s.params[s.pos] == ';') {
+		s.pos++
+	}
+
+	if s.pos >= len(s.params) {
+		return "", "", false
+	}
+
+	start := s.pos
+	for s.pos < len(s.params) && s.params[s.pos] != '=' {
+		s.pos++
+	}
+
+	name = strings.TrimRight(s.params[start:s.pos], " \t")
+	s.pos++
+
+	for s.pos < len(s.params) && isOWS(s.params[s.pos]) {
+		s.pos++
+	}
+
+	start = s.pos
+	if s.pos < len(s.params) && s.params[s.pos] == '"' {
+		s.pos, _ = quotedStringEnd(s.params, s.pos)
+	} else {
+		for s.pos < len(s.params) && isTokenChar(s.params[s.pos]) {
+			s.pos++
+		}
+	}
+
+	return name, s.params[start:s.pos], true
+}
+
+// quotedStringEnd returns position right after the quoted string started at the position.
+func quotedStringEnd(str string, pos int) (int, bool) {
+	for i := pos + 1; i < len(str); i++ {
+		switch str[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1, true
+		}
+	}
+
+	return len(str), false
+}
+
+// unquote returns the value of a quoted string with resolved quoted pairs, token is returned as is.
+func unquote(value string) string {
+	if !strings.HasPrefix(value, `"`) {
+		return value
+	}
+
+	value = value[1 : len(value)-1]
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+
+	var b strings.Builder
+
+	b.Grow(len(value))
+
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			i++
+		}
+
+		b.WriteByte(value[i])
+	}
+
+	return b.String()
+}
+
+// match matches the range with possible wildcards to the mime type by the same rules as MimeType.Match,
+// comparison of type and subtype is case-insensitive. The second parameter reports whether subtype matched by suffix.
+func (sr scannedRange) match(mtype MimeType, mode MatchMode) (matched, suffix bool) {
+	if !matchMimePartFold(sr.typ, mtype.Type) {
+		return false, false
+	}
+
+	if !matchMimePartFold(sr.subtype, mtype.Subtype) {
+		if mode&MatchSuffix == 0 || !strings.EqualFold(sr.subtype, mtype.Suffix()) {
+			return false, false
+		}
+
+		suffix = true
+	}
+
+	switch {
+	case mode&MatchParamsExact != 0:
+		return sr.nparams == len(mtype.Params) && sr.subsetParams(mtype.Params), suffix
+	case mode&MatchParamsSubset != 0:
+		return sr.subsetParams(mtype.Params), suffix
+	default:
+		return true, suffix
+	}
+}
+
+// subsetParams reports whether all media type params of the range are in params with equal values.
+func (sr scannedRange) subsetParams(params map[string]string) bool {
+	ps := paramScanner{params: sr.params}
+	for name, value, ok := ps.next(); ok; name, value, ok = ps.next() {
+		pvalue, ok := lookupParam(params, name)
+		if !ok || !equalParamValues(name, unquote(value), pvalue) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// lessSpecific reports whether the range is less specific than other one, like lessSpecificRange.
+func (sr scannedRange) lessSpecific(other scannedRange) bool {
+	return lessSpecificRange(sr.specificity(), other.specificity())
+}
+
+// specificity returns specificity of the scanned range.
+func (sr scannedRange) specificity() rangeSpecificity {
+	return rangeSpecificity{typ: sr.typ, subtype: sr.subtype, suffix: sr.suffix, nparams: sr.nparams}
+}
+
+// mimeHeader materializes the scanned range to MimeHeader.
+// Params and Extensions are allocated only if the range has media type parameters or accept extensions.
+func (sr scannedRange) mimeHeader() MimeHeader {
+	mh := MimeHeader{
+		MimeType: MimeType{
+			Type:    strings.ToLower(sr.typ),
+			Subtype: strings.ToLower(sr.subtype),
+		},
+		Quality: sr.quality,
+	}
+
+	mh.Params = materializeParams(sr.params)
+	mh.Extensions = materializeParams(sr.exts)
+
+	return mh
+}
+
+// materializeParams returns map of raw parameters or nil if there are no parameters.
+func materializeParams(params string) map[string]string {
+	if params == "" {
+		return nil
+	}
+
+	var mparams map[string]string
+
+	ps := paramScanner{params: params}
+	for name, value, ok := ps.next(); ok; name, value, ok = ps.next() {
+		if mparams == nil {
+			mparams = make(map[string]string)
+		}
+
+		mparams[strings.ToLower(name)] = unquote(value)
+	}
+
+	return mparams
+}
+
+func matchMimePartFold(b, t string) bool {
+	return b == MimeAny || t == MimeAny || strings.EqualFold(b, t)
+}
+
+// isOWS reports whether the character is an optional whitespace.
+func isOWS(c byte) bool {
+	return c == ' ' || c == '\t'
+}
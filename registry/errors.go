@@ -0,0 +1,83 @@
+package registry
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Error messages.
+const (
+	TypeErrMsg         = "invalid media type"
+	TypeWildcardErrMsg = "media type cannot be a wildcard"
+	ExtensionErrMsg    = "invalid file extension"
+
+	LoadReadErrMsg   = "error in reading of a type map"
+	LoadSyntaxErrMsg = "invalid syntax of a type map"
+	LoadEntryErrMsg  = "invalid entry of a type map"
+	ConflictErrMsg   = "conflicting extensions in a type map"
+)
+
+type TypeErr struct {
+	Err      error
+	Msg      string
+	MimeType string
+}
+
+func (e TypeErr) Error() string {
+	if e.Err == nil {
+		return e.Msg + " " + strconv.Quote(e.MimeType)
+	}
+
+	return e.Msg + " " + strconv.Quote(e.MimeType) + ": " + e.Err.Error()
+}
+
+func (e TypeErr) Unwrap() error {
+	return e.Err
+}
+
+type ExtensionErr struct {
+	Msg       string
+	Extension string
+}
+
+func (e ExtensionErr) Error() string {
+	return e.Msg + " " + strconv.Quote(e.Extension)
+}
+
+type LoadErr struct {
+	Err error
+	Msg string
+	// Line is a line number of the error, it's 0 for reading errors.
+	Line int
+}
+
+func (e LoadErr) Error() string {
+	msg := e.Msg
+	if e.Line > 0 {
+		msg = "line " + strconv.Itoa(e.Line) + ": " + msg
+	}
+
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+
+	return msg
+}
+
+func (e LoadErr) Unwrap() error {
+	return e.Err
+}
+
+type ConflictErr struct {
+	Msg       string
+	Conflicts []Conflict
+}
+
+func (e ConflictErr) Error() string {
+	conflicts := make([]string, 0, len(e.Conflicts))
+	for _, c := range e.Conflicts {
+		conflicts = append(conflicts, c.String())
+	}
+
+	return e.Msg + ": " + strings.Join(conflicts, "; ")
+}
@@ -0,0 +1,135 @@
+package registry
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+// MergePolicy defines how loaded type maps are merged with mappings of a registry.
+type MergePolicy int
+
+const (
+	// MergeOverride remaps extensions of other types to loaded types, like the last "types" block of nginx wins.
+	MergeOverride MergePolicy = iota
+	// MergeKeep keeps extensions mapped to other types, loaded mappings are added only for new extensions.
+	MergeKeep
+	// MergeStrict rejects type maps with conflicts by ConflictErr, nothing is loaded in this case.
+	MergeStrict
+)
+
+// Conflict describes an extension of a loaded entry which was already mapped to another media type,
+// by the registry or by a previous entry of the type map.
+type Conflict struct {
+	// Extension is the conflicting extension with leading dot.
+	Extension string
+	// Existing is the media type which the extension was mapped to.
+	Existing string
+	// Loaded is the media type of the loaded entry.
+	Loaded string
+	// Line is a line number of the loaded entry.
+	Line int
+}
+
+func (c Conflict) String() string {
+	return "line " + strconv.Itoa(c.Line) + ": extension " + strconv.Quote(c.Extension) +
+		" of " + strconv.Quote(c.Loaded) + " is mapped to " + strconv.Quote(c.Existing)
+}
+
+// loadEntry is a parsed entry of a type map.
+type loadEntry struct {
+	mtype string
+	exts  []string
+	line  int
+}
+
+// LoadMimeTypes loads the type map in Apache mime.types format: every line contains a media type
+// and its extensions without leading dots, separated by whitespaces, "#" starts a comment.
+// Types without extensions are skipped.
+// Conflicts are returned for every extension mapped to another type and resolved by the policy.
+// LoadErr is returned for invalid entries and ConflictErr for conflicts with MergeStrict, nothing is loaded in this case.
+func (r *Registry) LoadMimeTypes(rd io.Reader, policy MergePolicy) ([]Conflict, error) {
+	data, err := io.ReadAll(rd)
+	if err != nil {
+		return nil, LoadErr{Err: err, Msg: LoadReadErrMsg}
+	}
+
+	lines := strings.Split(string(data), "\n")
+	entries := make([]loadEntry, 0, len(lines))
+
+	for idx, line := range lines {
+		if comment := strings.Index(line, commentPrefix); comment >= 0 {
+			line = line[:comment]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		entries = append(entries, loadEntry{mtype: fields[0], exts: fields[1:], line: idx + 1})
+	}
+
+	return r.merge(entries, policy)
+}
+
+// merge validates all entries and merges them by the policy.
+func (r *Registry) merge(entries []loadEntry, policy MergePolicy) ([]Conflict, error) {
+	types := make([]mimeheader.MimeType, len(entries))
+	exts := make([][]string, len(entries))
+	// loaded are media types of extensions mapped by previous entries.
+	loaded := map[string]string{}
+
+	var conflicts []Conflict
+
+	for idx, entry := range entries {
+		mt, normalized, err := parseEntry(entry.mtype, entry.exts)
+		if err != nil {
+			return nil, LoadErr{Err: err, Msg: LoadEntryErrMsg, Line: entry.line}
+		}
+
+		types[idx] = mt
+		key := mt.String()
+
+		for _, ext := range normalized {
+			existing, ok := loaded[ext]
+			if !ok {
+				if prev, found := r.types[ext]; found {
+					existing, ok = prev.String(), true
+				}
+			}
+
+			if ok && existing != key {
+				conflicts = append(conflicts, Conflict{Extension: ext, Existing: existing, Loaded: key, Line: entry.line})
+
+				if policy == MergeKeep {
+					continue
+				}
+			}
+
+			loaded[ext] = key
+			exts[idx] = append(exts[idx], ext)
+		}
+	}
+
+	if policy == MergeStrict && len(conflicts) > 0 {
+		return conflicts, ConflictErr{Msg: ConflictErrMsg, Conflicts: conflicts}
+	}
+
+	for idx := range entries {
+		r.add(types[idx], exts[idx])
+	}
+
+	return conflicts, nil
+}
+
+// lineAt returns a line number of the byte offset.
+func lineAt(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+
+	return strings.Count(string(data[:offset]), "\n") + 1
+}
@@ -0,0 +1,57 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// LoadJSON loads the type map in JSON format: an object with media types as keys and arrays of extensions as values,
+// the leading dot of extensions is optional. Entries are merged in order of keys:
+//
+//	{"text/html": [".html", ".htm"], "image/jpeg": [".jpg", ".jpeg"]}
+//
+// Conflicts, policy and errors are the same as for Registry.LoadMimeTypes, lines are counted from the start of the input.
+func (r *Registry) LoadJSON(rd io.Reader, policy MergePolicy) ([]Conflict, error) {
+	data, err := io.ReadAll(rd)
+	if err != nil {
+		return nil, LoadErr{Err: err, Msg: LoadReadErrMsg}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	if token, err := dec.Token(); err != nil || token != json.Delim('{') {
+		return nil, LoadErr{Err: err, Msg: LoadSyntaxErrMsg + `: expected "{"`, Line: lineAt(data, dec.InputOffset())}
+	}
+
+	entries := make([]loadEntry, 0)
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, LoadErr{Err: err, Msg: LoadSyntaxErrMsg, Line: lineAt(data, dec.InputOffset())}
+		}
+
+		// Keys of JSON objects are always strings.
+		mtype, _ := token.(string)
+		line := lineAt(data, dec.InputOffset())
+
+		var exts []string
+		if err := dec.Decode(&exts); err != nil {
+			return nil, LoadErr{Err: err, Msg: LoadSyntaxErrMsg, Line: line}
+		}
+
+		entries = append(entries, loadEntry{mtype: mtype, exts: exts, line: line})
+	}
+
+	if token, err := dec.Token(); err != nil || token != json.Delim('}') {
+		return nil, LoadErr{Err: err, Msg: LoadSyntaxErrMsg + `: expected "}"`, Line: lineAt(data, dec.InputOffset())}
+	}
+
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, LoadErr{Msg: LoadSyntaxErrMsg + ": unexpected data after the object", Line: lineAt(data, dec.InputOffset())}
+	}
+
+	return r.merge(entries, policy)
+}
@@ -0,0 +1,138 @@
+package registry
+
+import (
+	"io"
+	"strings"
+)
+
+// Tokens of nginx configuration.
+const (
+	nginxTypes      = "types"
+	nginxBlockStart = "{"
+	nginxBlockEnd   = "}"
+	nginxStatement  = ";"
+)
+
+// nginxToken is a word or a special character of nginx configuration with its line number.
+type nginxToken struct {
+	value string
+	line  int
+}
+
+// LoadNginxTypes loads the type map in nginx format, like mime.types file of nginx:
+//
+//	types {
+//	    text/html  html htm;
+//	    image/jpeg jpeg jpg;
+//	}
+//
+// Several "types" blocks can follow each other, "#" starts a comment.
+// Conflicts, policy and errors are the same as for Registry.LoadMimeTypes.
+func (r *Registry) LoadNginxTypes(rd io.Reader, policy MergePolicy) ([]Conflict, error) {
+	data, err := io.ReadAll(rd)
+	if err != nil {
+		return nil, LoadErr{Err: err, Msg: LoadReadErrMsg}
+	}
+
+	tokens := tokenizeNginx(string(data))
+	entries := make([]loadEntry, 0)
+
+	for len(tokens) > 0 {
+		if len(tokens) < 2 || tokens[0].value != nginxTypes || tokens[1].value != nginxBlockStart {
+			return nil, LoadErr{Msg: LoadSyntaxErrMsg + ": expected \"types {\"", Line: tokens[0].line}
+		}
+
+		tokens = tokens[2:]
+
+		var closed bool
+
+		for len(tokens) > 0 {
+			if tokens[0].value == nginxBlockEnd {
+				tokens, closed = tokens[1:], true
+
+				break
+			}
+
+			end := nginxStatementEnd(tokens)
+			if end < 0 {
+				return nil, LoadErr{Msg: LoadSyntaxErrMsg + ": expected \";\"", Line: tokens[0].line}
+			}
+
+			exts := make([]string, 0, end-1)
+			for _, token := range tokens[1:end] {
+				exts = append(exts, token.value)
+			}
+
+			entries = append(entries, loadEntry{mtype: tokens[0].value, exts: exts, line: tokens[0].line})
+			tokens = tokens[end+1:]
+		}
+
+		if !closed {
+			return nil, LoadErr{Msg: LoadSyntaxErrMsg + ": expected \"}\"", Line: lineAt(data, int64(len(data)))}
+		}
+	}
+
+	return r.merge(entries, policy)
+}
+
+// nginxStatementEnd returns index of ";" which ends the statement or -1 if the statement isn't terminated
+// or contains special characters.
+func nginxStatementEnd(tokens []nginxToken) int {
+	for idx, token := range tokens {
+		switch token.value {
+		case nginxStatement:
+			if idx == 0 {
+				return -1
+			}
+
+			return idx
+		case nginxBlockStart, nginxBlockEnd:
+			return -1
+		}
+	}
+
+	return -1
+}
+
+// tokenizeNginx splits nginx configuration into words and special characters, comments are skipped.
+func tokenizeNginx(data string) []nginxToken {
+	var tokens []nginxToken
+
+	line, start := 1, -1
+
+	flush := func(end int) {
+		if start >= 0 {
+			tokens = append(tokens, nginxToken{value: data[start:end], line: line})
+			start = -1
+		}
+	}
+
+	for idx := 0; idx < len(data); idx++ {
+		switch char := data[idx]; {
+		case char == '#':
+			flush(idx)
+
+			if end := strings.IndexByte(data[idx:], '\n'); end >= 0 {
+				idx += end - 1
+			} else {
+				idx = len(data)
+			}
+		case char == '\n':
+			flush(idx)
+			line++
+		case char == ' ' || char == '\t' || char == '\r':
+			flush(idx)
+		case strings.IndexByte(nginxBlockStart+nginxBlockEnd+nginxStatement, char) >= 0:
+			flush(idx)
+			tokens = append(tokens, nginxToken{value: data[idx : idx+1], line: line})
+		default:
+			if start < 0 {
+				start = idx
+			}
+		}
+	}
+
+	flush(len(data))
+
+	return tokens
+}
@@ -0,0 +1,247 @@
+package registry_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader/registry"
+)
+
+func ExampleRegistry_LoadNginxTypes() {
+	reg := registry.Default()
+
+	conflicts, err := reg.LoadNginxTypes(strings.NewReader(`
+types {
+    text/html                html htm shtml;
+    application/x-typescript ts;
+}
+`), registry.MergeOverride)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, c := range conflicts {
+		fmt.Println(c)
+	}
+
+	mtype, _ := reg.TypeByExtension(".ts")
+	fmt.Println(mtype.String(), reg.ExtensionsByType("text/html"))
+	// Output:
+	// line 4: extension ".ts" of "application/x-typescript" is mapped to "video/mp2t"
+	// application/x-typescript [.html .htm .shtml]
+}
+
+func TestRegistryLoad(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerRegistryLoad() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			reg := registry.New()
+			if err := reg.Add("video/mp2t", ".ts"); err != nil {
+				t.Fatal(err)
+			}
+
+			conflicts, err := prov.load(reg, strings.NewReader(prov.data), prov.policy)
+			if err != nil && err.Error() != prov.expErr || err == nil && prov.expErr != "" {
+				t.Fatalf("Wrong error.\nExpected: %s\nActual: %v", prov.expErr, err)
+			}
+
+			if !reflect.DeepEqual(conflicts, prov.expConflicts) {
+				t.Fatalf("Wrong conflicts.\nExpected: %v\nActual: %v", prov.expConflicts, conflicts)
+			}
+
+			for ext, exp := range prov.expTypes {
+				mtype, _ := reg.TypeByExtension(ext)
+				if mtype.String() != exp {
+					t.Fatalf("Wrong type of %s.\nExpected: %s\nActual: %s", ext, exp, mtype.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRegistryLoadErr(t *testing.T) {
+	t.Parallel()
+
+	reg := registry.New()
+
+	_, err := reg.LoadMimeTypes(strings.NewReader("text/html html\ntext/* txt\n"), registry.MergeOverride)
+
+	var loadErr registry.LoadErr
+	if !errors.As(err, &loadErr) || loadErr.Line != 2 {
+		t.Fatalf("Wrong error: %v", err)
+	}
+
+	var typeErr registry.TypeErr
+	if !errors.As(err, &typeErr) || typeErr.MimeType != "text/*" {
+		t.Fatalf("Error of the entry is not wrapped: %v", err)
+	}
+
+	if len(reg.Types()) != 0 {
+		t.Fatalf("Entries were loaded: %v", reg.Types())
+	}
+}
+
+type loadFunc func(reg *registry.Registry, data *strings.Reader, policy registry.MergePolicy) ([]registry.Conflict, error)
+
+func loadMimeTypes(reg *registry.Registry, data *strings.Reader, policy registry.MergePolicy) ([]registry.Conflict, error) {
+	return reg.LoadMimeTypes(data, policy)
+}
+
+func loadNginxTypes(reg *registry.Registry, data *strings.Reader, policy registry.MergePolicy) ([]registry.Conflict, error) {
+	return reg.LoadNginxTypes(data, policy)
+}
+
+func loadJSON(reg *registry.Registry, data *strings.Reader, policy registry.MergePolicy) ([]registry.Conflict, error) {
+	return reg.LoadJSON(data, policy)
+}
+
+type registryLoad struct {
+	name         string
+	load         loadFunc
+	data         string
+	policy       registry.MergePolicy
+	expTypes     map[string]string
+	expConflicts []registry.Conflict
+	expErr       string
+}
+
+func providerRegistryLoad() []registryLoad {
+	return []registryLoad{
+		{
+			name: "mime.types",
+			load: loadMimeTypes,
+			data: "# Comment\n\ntext/html\t\thtml htm # Inline comment\napplication/x-empty\nimage/jpeg jpeg jpg\n",
+			expTypes: map[string]string{
+				".html": "text/html",
+				".htm":  "text/html",
+				".jpg":  "image/jpeg",
+				".ts":   "video/mp2t",
+			},
+		},
+		{
+			name:   "mime.types override",
+			load:   loadMimeTypes,
+			data:   "text/html html\napplication/typescript ts\n",
+			policy: registry.MergeOverride,
+			expTypes: map[string]string{
+				".ts": "application/typescript",
+			},
+			expConflicts: []registry.Conflict{
+				{Extension: ".ts", Existing: "video/mp2t", Loaded: "application/typescript", Line: 2},
+			},
+		},
+		{
+			name:   "mime.types keep",
+			load:   loadMimeTypes,
+			data:   "text/html html\napplication/typescript ts\n",
+			policy: registry.MergeKeep,
+			expTypes: map[string]string{
+				".html": "text/html",
+				".ts":   "video/mp2t",
+			},
+			expConflicts: []registry.Conflict{
+				{Extension: ".ts", Existing: "video/mp2t", Loaded: "application/typescript", Line: 2},
+			},
+		},
+		{
+			name:   "mime.types strict",
+			load:   loadMimeTypes,
+			data:   "text/html html\napplication/typescript ts\n",
+			policy: registry.MergeStrict,
+			expTypes: map[string]string{
+				".html": "",
+				".ts":   "video/mp2t",
+			},
+			expConflicts: []registry.Conflict{
+				{Extension: ".ts", Existing: "video/mp2t", Loaded: "application/typescript", Line: 2},
+			},
+			expErr: `conflicting extensions in a type map: line 2: extension ".ts" of "application/typescript" is mapped to "video/mp2t"`,
+		},
+		{
+			name:   "Conflict inside a type map",
+			load:   loadMimeTypes,
+			data:   "audio/x-wav wav\naudio/wav wav\n",
+			policy: registry.MergeKeep,
+			expTypes: map[string]string{
+				".wav": "audio/x-wav",
+			},
+			expConflicts: []registry.Conflict{
+				{Extension: ".wav", Existing: "audio/x-wav", Loaded: "audio/wav", Line: 2},
+			},
+		},
+		{
+			name: "nginx",
+			load: loadNginxTypes,
+			data: "types {\n  text/html html;# Comment\n  image/jpeg\n    jpeg jpg;\n}\ntypes{text/css css;}\n",
+			expTypes: map[string]string{
+				".html": "text/html",
+				".jpg":  "image/jpeg",
+				".css":  "text/css",
+			},
+		},
+		{
+			name:   "nginx without types block",
+			load:   loadNginxTypes,
+			data:   "text/html html;\n",
+			expErr: `line 1: invalid syntax of a type map: expected "types {"`,
+		},
+		{
+			name:   "nginx without semicolon",
+			load:   loadNginxTypes,
+			data:   "types {\n  text/html html\n}\n",
+			expErr: `line 2: invalid syntax of a type map: expected ";"`,
+		},
+		{
+			name:   "nginx without closed block",
+			load:   loadNginxTypes,
+			data:   "types {\n  text/html html;\n",
+			expErr: `line 3: invalid syntax of a type map: expected "}"`,
+		},
+		{
+			name:   "nginx invalid type",
+			load:   loadNginxTypes,
+			data:   "types {\n  text/html html;\n  html text;\n}",
+			expErr: `line 3: invalid entry of a type map: invalid media type "html": wrong number of mime type parts`,
+			expTypes: map[string]string{
+				".html": "",
+			},
+		},
+		{
+			name: "JSON",
+			load: loadJSON,
+			data: "{\n  \"text/html\": [\".html\", \"htm\"],\n  \"application/typescript\": [\".ts\"]\n}\n",
+			expTypes: map[string]string{
+				".htm": "text/html",
+				".ts":  "application/typescript",
+			},
+			expConflicts: []registry.Conflict{
+				{Extension: ".ts", Existing: "video/mp2t", Loaded: "application/typescript", Line: 3},
+			},
+		},
+		{
+			name:   "JSON not an object",
+			load:   loadJSON,
+			data:   `["text/html"]`,
+			expErr: `line 1: invalid syntax of a type map: expected "{"`,
+		},
+		{
+			name:   "JSON invalid extensions",
+			load:   loadJSON,
+			data:   "{\n  \"text/html\": \".html\"\n}",
+			expErr: "line 2: invalid syntax of a type map: json: cannot unmarshal string into Go value of type []string",
+		},
+		{
+			name:   "JSON data after the object",
+			load:   loadJSON,
+			data:   "{}\n{}",
+			expErr: `line 2: invalid syntax of a type map: unexpected data after the object`,
+		},
+	}
+}
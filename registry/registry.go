@@ -0,0 +1,201 @@
+// Package registry maps file extensions to media types and back.
+// It doesn't depend on mime.types files of a host, unlike mime.TypeByExtension,
+// the default registry is seeded from an embedded snapshot of common types.
+package registry
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+// ExtensionSeparator is a prefix of file extensions, like ".json".
+const ExtensionSeparator = "."
+
+// Registry maps file extensions to media types and back.
+// Lookups are safe for concurrent use, but Registry.Add MUST NOT be called concurrently with them.
+type Registry struct {
+	// types are media types by extensions.
+	types map[string]mimeheader.MimeType
+	// exts are extensions by media types without params, the preferred extension is the first one.
+	exts map[string][]string
+}
+
+// New creates an empty Registry.
+func New() *Registry {
+	return &Registry{
+		types: map[string]mimeheader.MimeType{},
+		exts:  map[string][]string{},
+	}
+}
+
+// Default creates Registry seeded from the embedded snapshot of common types.
+// The snapshot is parsed once, every call returns a copy which can be modified independently.
+func Default() *Registry {
+	return snapshotTypes().clone()
+}
+
+// Add maps extensions to the media type and the media type to extensions, like Add("image/jpeg", ".jpg", ".jpeg").
+// Extensions are compared case-insensitively, the leading dot is optional.
+// An extension of another type is remapped to the media type. The first extension added to a type is the preferred one.
+// Media type can contain params, like "text/html; charset=utf-8", they are returned by Registry.TypeByExtension.
+// TypeErr is returned for invalid or wildcard type and ExtensionErr for invalid extension, nothing is added in this case.
+func (r *Registry) Add(mtype string, exts ...string) error {
+	mt, normalized, err := parseEntry(mtype, exts)
+	if err != nil {
+		return err
+	}
+
+	r.add(mt, normalized)
+
+	return nil
+}
+
+// TypeByExtension returns media type of the extension, like ".webp" or "webp".
+// The last parameter is false, if the extension is unknown.
+func (r *Registry) TypeByExtension(ext string) (mimeheader.MimeType, bool) {
+	norm, ok := normalizeExtension(ext)
+	if !ok {
+		return mimeheader.MimeType{}, false
+	}
+
+	mt, ok := r.types[norm]
+	if !ok {
+		return mimeheader.MimeType{}, false
+	}
+
+	params := make(map[string]string, len(mt.Params))
+	for name, value := range mt.Params {
+		params[name] = value
+	}
+
+	mt.Params = params
+
+	return mt, true
+}
+
+// ExtensionsByType returns extensions of the media type with leading dots, the preferred extension is the first one.
+// Params of the media type are ignored. Nil is returned for unknown or invalid type.
+func (r *Registry) ExtensionsByType(mtype string) []string {
+	mt, err := mimeheader.ParseMediaType(mtype)
+	if err != nil {
+		return nil
+	}
+
+	exts := r.exts[mt.String()]
+	if len(exts) == 0 {
+		return nil
+	}
+
+	return append([]string(nil), exts...)
+}
+
+// Types returns registered media types without params in lexical order.
+func (r *Registry) Types() []string {
+	types := make([]string, 0, len(r.exts))
+
+	for mtype, exts := range r.exts {
+		if len(exts) > 0 {
+			types = append(types, mtype)
+		}
+	}
+
+	sort.Strings(types)
+
+	return types
+}
+
+// clone returns a deep copy of the registry.
+func (r *Registry) clone() *Registry {
+	c := &Registry{
+		types: make(map[string]mimeheader.MimeType, len(r.types)),
+		exts:  make(map[string][]string, len(r.exts)),
+	}
+
+	// Media types are never modified after adding, params are copied by Registry.TypeByExtension.
+	for ext, mt := range r.types {
+		c.types[ext] = mt
+	}
+
+	for mtype, exts := range r.exts {
+		c.exts[mtype] = append([]string(nil), exts...)
+	}
+
+	return c
+}
+
+// parseEntry parses and validates the media type and extensions of an entry, extensions are normalized.
+func parseEntry(mtype string, exts []string) (mimeheader.MimeType, []string, error) {
+	mt, err := mimeheader.ParseMediaType(mtype)
+	if err != nil {
+		return mimeheader.MimeType{}, nil, TypeErr{Err: err, Msg: TypeErrMsg, MimeType: mtype}
+	}
+
+	if mt.Type == mimeheader.MimeAny || mt.Subtype == mimeheader.MimeAny {
+		return mimeheader.MimeType{}, nil, TypeErr{Msg: TypeWildcardErrMsg, MimeType: mtype}
+	}
+
+	normalized := make([]string, 0, len(exts))
+
+	for _, ext := range exts {
+		norm, ok := normalizeExtension(ext)
+		if !ok {
+			return mimeheader.MimeType{}, nil, ExtensionErr{Msg: ExtensionErrMsg, Extension: ext}
+		}
+
+		normalized = append(normalized, norm)
+	}
+
+	return mt, normalized, nil
+}
+
+// add maps normalized extensions to the media type and back.
+func (r *Registry) add(mt mimeheader.MimeType, exts []string) {
+	key := mt.String()
+
+	for _, ext := range exts {
+		if prev, ok := r.types[ext]; ok && prev.String() != key {
+			r.exts[prev.String()] = removeExtension(r.exts[prev.String()], ext)
+		}
+
+		r.types[ext] = mt
+
+		if !containsExtension(r.exts[key], ext) {
+			r.exts[key] = append(r.exts[key], ext)
+		}
+	}
+}
+
+// normalizeExtension lowercases the extension and adds the leading dot.
+// The last parameter is false for an empty extension or an extension with dots, slashes or spaces inside.
+func normalizeExtension(ext string) (string, bool) {
+	ext = strings.TrimPrefix(ext, ExtensionSeparator)
+	if ext == "" || strings.ContainsAny(ext, "./\\ \t") {
+		return "", false
+	}
+
+	return ExtensionSeparator + strings.ToLower(ext), true
+}
+
+func containsExtension(exts []string, ext string) bool {
+	for _, e := range exts {
+		if e == ext {
+			return true
+		}
+	}
+
+	return false
+}
+
+func removeExtension(exts []string, ext string) []string {
+	filtered := exts[:0]
+
+	for _, e := range exts {
+		if e != ext {
+			filtered = append(filtered, e)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,277 @@
+package registry_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+	"github.com/aohorodnyk/mimeheader/registry"
+)
+
+func ExampleTypeByExtension() {
+	mtype, ok := registry.TypeByExtension(".webp")
+	fmt.Println(mtype.String(), ok)
+
+	fmt.Println(registry.ExtensionsByType("image/jpeg"))
+	// Output:
+	// image/webp true
+	// [.jpg .jpeg .jpe]
+}
+
+func ExampleRegistry_Add() {
+	reg := registry.Default()
+	if err := reg.Add("text/x-go; charset=utf-8", ".go"); err != nil {
+		panic(err)
+	}
+
+	mtype, _ := reg.TypeByExtension(filepath.Ext("cmd/main.go"))
+	fmt.Println(mtype.StringWithParams())
+	// Output:
+	// text/x-go; charset=utf-8
+}
+
+func TestDefault(t *testing.T) {
+	t.Parallel()
+
+	reg := registry.Default()
+
+	types := reg.Types()
+	if len(types) == 0 {
+		t.Fatal("Default registry is empty")
+	}
+
+	for _, mtype := range types {
+		exts := reg.ExtensionsByType(mtype)
+		if !reflect.DeepEqual(exts, registry.ExtensionsByType(mtype)) {
+			t.Fatalf("Extensions of %s differ from the snapshot: %v", mtype, exts)
+		}
+
+		for _, ext := range exts {
+			actual, ok := reg.TypeByExtension(ext)
+			if !ok || actual.String() != mtype {
+				t.Fatalf("Extension %s is mapped to %s, expected %s", ext, actual.String(), mtype)
+			}
+
+			actual, ok = registry.TypeByExtension(ext)
+			if !ok || actual.String() != mtype {
+				t.Fatalf("Extension %s is mapped to %s in the snapshot, expected %s", ext, actual.String(), mtype)
+			}
+		}
+	}
+}
+
+func TestDefaultSnapshotConflicts(t *testing.T) {
+	t.Parallel()
+
+	file, err := os.Open("mime.types")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	if _, err := registry.New().LoadMimeTypes(file, registry.MergeStrict); err != nil {
+		t.Fatalf("Snapshot maps extensions to several types: %v", err)
+	}
+}
+
+func TestDefaultCopy(t *testing.T) {
+	t.Parallel()
+
+	reg := registry.Default()
+	if err := reg.Add("application/x-test", ".jpg", ".test"); err != nil {
+		t.Fatal(err)
+	}
+
+	if mtype, _ := registry.Default().TypeByExtension(".jpg"); mtype.String() != "image/jpeg" {
+		t.Fatalf("Default registry is modified by a copy: %s", mtype.String())
+	}
+
+	if exts := registry.ExtensionsByType("image/jpeg"); !reflect.DeepEqual(exts, []string{".jpg", ".jpeg", ".jpe"}) {
+		t.Fatalf("Snapshot registry is modified by a copy: %v", exts)
+	}
+
+	if _, ok := registry.TypeByExtension(".test"); ok {
+		t.Fatal("Snapshot registry is modified by a copy")
+	}
+}
+
+func TestRegistryTypeByExtension(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerRegistryTypeByExtension() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			mtype, ok := registry.Default().TypeByExtension(prov.ext)
+			if ok != prov.expOk || mtype.StringWithParams() != prov.exp {
+				t.Fatalf("Wrong type.\nExpected: %s, %t\nActual: %s, %t", prov.exp, prov.expOk, mtype.StringWithParams(), ok)
+			}
+
+			mtype, ok = registry.TypeByExtension(prov.ext)
+			if ok != prov.expOk || mtype.StringWithParams() != prov.exp {
+				t.Fatalf("Wrong snapshot type.\nExpected: %s, %t\nActual: %s, %t", prov.exp, prov.expOk, mtype.StringWithParams(), ok)
+			}
+		})
+	}
+}
+
+func TestRegistryExtensionsByType(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerRegistryExtensionsByType() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			if exts := registry.Default().ExtensionsByType(prov.mtype); !reflect.DeepEqual(exts, prov.exp) {
+				t.Fatalf("Wrong extensions.\nExpected: %v\nActual: %v", prov.exp, exts)
+			}
+
+			if exts := registry.ExtensionsByType(prov.mtype); !reflect.DeepEqual(exts, prov.exp) {
+				t.Fatalf("Wrong snapshot extensions.\nExpected: %v\nActual: %v", prov.exp, exts)
+			}
+		})
+	}
+}
+
+func TestRegistryAdd(t *testing.T) {
+	t.Parallel()
+
+	reg := registry.New()
+
+	if err := reg.Add("image/jpeg", "jpg", ".JPEG"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := reg.Add("image/jpeg", ".jpe", ".jpg"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := reg.Add("image/pjpeg", ".jpe"); err != nil {
+		t.Fatal(err)
+	}
+
+	if exts := reg.ExtensionsByType("image/jpeg"); !reflect.DeepEqual(exts, []string{".jpg", ".jpeg"}) {
+		t.Fatalf("Wrong extensions: %v", exts)
+	}
+
+	if mtype, _ := reg.TypeByExtension("jpe"); mtype.String() != "image/pjpeg" {
+		t.Fatalf("Extension is not remapped: %s", mtype.String())
+	}
+
+	if types := reg.Types(); !reflect.DeepEqual(types, []string{"image/jpeg", "image/pjpeg"}) {
+		t.Fatalf("Wrong types: %v", types)
+	}
+
+	mtype, _ := reg.TypeByExtension(".jpg")
+	mtype.Params["changed"] = "1"
+
+	if mtype, _ := reg.TypeByExtension(".jpg"); len(mtype.Params) != 0 {
+		t.Fatalf("Params of registered type are shared: %v", mtype.Params)
+	}
+}
+
+func TestRegistryAddErr(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerRegistryAddErr() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			reg := registry.New()
+
+			err := reg.Add(prov.mtype, prov.exts...)
+			if err == nil || err.Error() != prov.exp {
+				t.Fatalf("Wrong error.\nExpected: %s\nActual: %v", prov.exp, err)
+			}
+
+			var typeErr registry.TypeErr
+			var extErr registry.ExtensionErr
+			if !errors.As(err, &typeErr) && !errors.As(err, &extErr) {
+				t.Fatalf("Unexpected error type: %T", err)
+			}
+
+			if len(reg.Types()) != 0 {
+				t.Fatalf("Invalid type was added: %v", reg.Types())
+			}
+		})
+	}
+}
+
+type registryTypeByExtension struct {
+	name  string
+	ext   string
+	exp   string
+	expOk bool
+}
+
+func providerRegistryTypeByExtension() []registryTypeByExtension {
+	return []registryTypeByExtension{
+		{name: "With dot", ext: ".webp", exp: "image/webp", expOk: true},
+		{name: "Without dot", ext: "json", exp: "application/json", expOk: true},
+		{name: "Upper case", ext: ".HTML", exp: "text/html", expOk: true},
+		{name: "Not preferred", ext: ".jpeg", exp: "image/jpeg", expOk: true},
+		{name: "Unknown", ext: ".unknown"},
+		{name: "Empty", ext: ""},
+		{name: "Dot", ext: "."},
+		{name: "Path", ext: "/etc/mime.types"},
+	}
+}
+
+type registryExtensionsByType struct {
+	name  string
+	mtype string
+	exp   []string
+}
+
+func providerRegistryExtensionsByType() []registryExtensionsByType {
+	return []registryExtensionsByType{
+		{name: "Preferred first", mtype: "image/jpeg", exp: []string{".jpg", ".jpeg", ".jpe"}},
+		{name: "Params are ignored", mtype: "text/html; charset=utf-8", exp: []string{".html", ".htm"}},
+		{name: "Case insensitive", mtype: "Application/JSON", exp: []string{".json"}},
+		{name: "Unknown", mtype: "application/x-unknown"},
+		{name: "Invalid", mtype: "json"},
+	}
+}
+
+type registryAddErr struct {
+	name  string
+	mtype string
+	exts  []string
+	exp   string
+}
+
+func providerRegistryAddErr() []registryAddErr {
+	return []registryAddErr{
+		{
+			name:  "Invalid type",
+			mtype: "json",
+			exts:  []string{".json"},
+			exp:   `invalid media type "json": ` + mimeheader.MimeTypePartsErrMsg,
+		},
+		{
+			name:  "Wildcard",
+			mtype: "image/*",
+			exts:  []string{".img"},
+			exp:   `media type cannot be a wildcard "image/*"`,
+		},
+		{
+			name:  "Empty extension",
+			mtype: "image/png",
+			exts:  []string{".png", ""},
+			exp:   `invalid file extension ""`,
+		},
+		{
+			name:  "Multiple dots",
+			mtype: "application/gzip",
+			exts:  []string{".tar.gz"},
+			exp:   `invalid file extension ".tar.gz"`,
+		},
+	}
+}
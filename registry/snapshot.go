@@ -0,0 +1,80 @@
+package registry
+
+import (
+	_ "embed"
+	"strings"
+	"sync"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+// snapshot contains common media types with extensions in mime.types format.
+//
+//go:embed mime.types
+var snapshot string
+
+// commentPrefix starts a comment line in mime.types format.
+const commentPrefix = "#"
+
+// snapshotRegistry is built from the embedded snapshot on the first use and never modified after that.
+//
+//nolint:gochecknoglobals // The registry is shared by lookups to parse the snapshot only once.
+var snapshotRegistry struct {
+	once sync.Once
+	reg  *Registry
+}
+
+// TypeByExtension returns media type of the extension from the embedded snapshot, like Registry.TypeByExtension.
+// The snapshot is parsed once on the first lookup.
+func TypeByExtension(ext string) (mimeheader.MimeType, bool) {
+	return snapshotTypes().TypeByExtension(ext)
+}
+
+// ExtensionsByType returns extensions of the media type from the embedded snapshot, like Registry.ExtensionsByType.
+// The snapshot is parsed once on the first lookup.
+func ExtensionsByType(mtype string) []string {
+	return snapshotTypes().ExtensionsByType(mtype)
+}
+
+// snapshotTypes returns the shared registry of the embedded snapshot, it MUST NOT be modified.
+func snapshotTypes() *Registry {
+	snapshotRegistry.once.Do(func() {
+		r := New()
+
+		scanSnapshot(func(mtype string, exts []string) bool {
+			// The snapshot is verified by tests, its types and extensions are always valid.
+			_ = r.Add(mtype, exts...)
+
+			return true
+		})
+
+		snapshotRegistry.reg = r
+	})
+
+	return snapshotRegistry.reg
+}
+
+// scanSnapshot calls the function for every media type of the snapshot with its extensions without leading dots,
+// until it returns false.
+func scanSnapshot(fn func(mtype string, exts []string) bool) {
+	data := snapshot
+
+	for data != "" {
+		var line string
+
+		if idx := strings.IndexByte(data, '\n'); idx >= 0 {
+			line, data = data[:idx], data[idx+1:]
+		} else {
+			line, data = data, ""
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], commentPrefix) {
+			continue
+		}
+
+		if !fn(fields[0], fields[1:]) {
+			return
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package mimeheader
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// Error messages of Renderer.
+const (
+	NotAcceptableErrMsg  = "none of offered mime types is acceptable"
+	DefaultEncoderErrMsg = "default mime type has no encoder"
+	InvalidDefaultErrMsg = "invalid default mime type"
+	EncodeErrMsg         = "error in encoding of a response"
+	CSVValueErrMsg       = "csv encoder supports only [][]string and slices of structs"
+)
+
+// charsetUTF8 is a charset of rendered text responses.
+const charsetUTF8 = "utf-8"
+
+// Encoder encodes a value to a response body.
+type Encoder interface {
+	Encode(w io.Writer, v interface{}) error
+}
+
+// EncoderFunc is an adapter to use ordinary functions as Encoder.
+type EncoderFunc func(w io.Writer, v interface{}) error
+
+// Encode calls f(w, v).
+func (f EncoderFunc) Encode(w io.Writer, v interface{}) error {
+	return f(w, v)
+}
+
+// RendererOptions configures Renderer.
+type RendererOptions struct {
+	// Default type is rendered when none of registered types is acceptable.
+	// It's registered by NewRenderer with DefaultEncoder as the first type, so it doesn't need to be registered again.
+	// Renderer responds with 406 Not Acceptable listing registered types, if default type is empty.
+	Default string
+	// DefaultEncoder encodes responses of the default type. It MUST be set with Default and is ignored without it.
+	DefaultEncoder Encoder
+}
+
+// Renderer encodes responses by a mime type negotiated from Accept header of a request.
+// Types are registered with encoders and negotiated like by AcceptHeader.NegotiateResult: on equal quality
+// the type matched by more specific range wins, then the type matched by the earlier range of Accept header,
+// then the type registered first.
+// Renderer is safe for concurrent use after registration.
+type Renderer struct {
+	offers []Offer[Encoder]
+	opts   RendererOptions
+}
+
+// NewRenderer creates Renderer with the default type registered, if it's set.
+// OfferErr is returned for invalid default type, like "text/" or "text/plain;qs=5", or default type without encoder.
+func NewRenderer(opts RendererOptions) (*Renderer, error) {
+	rr := &Renderer{opts: opts}
+
+	if opts.Default == "" {
+		return rr, nil
+	}
+
+	if opts.DefaultEncoder == nil {
+		return nil, OfferErr{Msg: DefaultEncoderErrMsg, Offer: opts.Default}
+	}
+
+	if err := rr.Register(opts.Default, opts.DefaultEncoder); err != nil {
+		return nil, OfferErr{Err: errors.Unwrap(err), Msg: InvalidDefaultErrMsg, Offer: opts.Default}
+	}
+
+	return rr, nil
+}
+
+// Register adds the mime type with the encoder. OfferErr is returned for invalid type or invalid source quality.
+// Type can contain params, like charset, and source quality "qs" param, like for AcceptHeader.NegotiateResult.
+func (rr *Renderer) Register(mtype string, enc Encoder) error {
+	parsed, err := ParseMediaType(mtype)
+	if err == nil {
+		_, err = cutSourceQuality(parsed)
+	}
+
+	if err != nil {
+		return OfferErr{Err: err, Msg: OfferErrMsg, Offer: mtype}
+	}
+
+	rr.offers = append(rr.offers, Offer[Encoder]{MimeType: mtype, Value: enc})
+
+	return nil
+}
+
+// Types returns registered mime types in order of registration.
+func (rr *Renderer) Types() []string {
+	types := make([]string, 0, len(rr.offers))
+	for _, offer := range rr.offers {
+		types = append(types, offer.MimeType)
+	}
+
+	return types
+}
+
+// Render negotiates a mime type by Accept header of the request and writes the value encoded by its encoder.
+// Content-Type header is set to the negotiated type with params, charset=utf-8 is added for text types without charset.
+// Vary header always contains Accept. Request without Accept header accepts any mime type.
+// The value is encoded before writing, so nothing is written if the encoder returns an error.
+// If none of types is acceptable, the default type is rendered, otherwise 406 Not Acceptable is written
+// and NotAcceptableErr is returned.
+func (rr *Renderer) Render(rw http.ResponseWriter, r *http.Request, v interface{}) error {
+	AddVary(rw.Header(), HeaderAccept)
+
+	enc, res, matched := Negotiate(ParseAcceptHeader(acceptHeader(r)), rr.offers)
+	if !matched {
+		var err error
+
+		enc, res, err = rr.defaultOffer()
+		if err != nil {
+			writeNotAcceptable(rw, rr.Types())
+
+			return err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := enc.Encode(&buf, v); err != nil {
+		return EncodeErr{Err: err, Msg: EncodeErrMsg, MimeType: res.MimeType.String()}
+	}
+
+	rw.Header().Set(HeaderContentType, contentType(res.MimeType))
+	rw.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+
+	_, err := buf.WriteTo(rw)
+
+	return err
+}
+
+// defaultOffer returns encoder and negotiation result of the default type, it's the first registered type.
+func (rr *Renderer) defaultOffer() (Encoder, NegotiationResult, error) {
+	if rr.opts.Default == "" {
+		return nil, NegotiationResult{}, NotAcceptableErr{Msg: NotAcceptableErrMsg, Types: rr.Types()}
+	}
+
+	offer := rr.offers[0]
+
+	return offer.Value, defaultResult(offer.MimeType), nil
+}
+
+// contentType formats Content-Type header value, charset=utf-8 is added for text types without charset.
+func contentType(mtype MimeType) string {
+	if !isTextType(mtype) {
+		return mtype.StringWithParams()
+	}
+
+	if _, ok := lookupParam(mtype.Params, CharsetParam); ok {
+		return mtype.StringWithParams()
+	}
+
+	params := make(map[string]string, len(mtype.Params)+1)
+	for name, value := range mtype.Params {
+		params[name] = value
+	}
+
+	params[CharsetParam] = charsetUTF8
+	mtype.Params = params
+
+	return mtype.StringWithParams()
+}
+
+// isTextType reports whether the type is a text type with charset param, like "text/*" or XML types (RFC 7303).
+// JSON doesn't define charset param (RFC 8259 Sec 11).
+func isTextType(mtype MimeType) bool {
+	return mtype.Type == "text" || mtype.Subtype == "xml" || mtype.Suffix() == "xml"
+}
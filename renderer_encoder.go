@@ -0,0 +1,125 @@
+package mimeheader
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"io"
+	"reflect"
+)
+
+// csvTag is a struct field tag with a column name for CSVEncoder, "-" skips the field.
+const csvTag = "csv"
+
+// JSONEncoder encodes values by encoding/json.
+func JSONEncoder() Encoder {
+	return EncoderFunc(func(w io.Writer, v interface{}) error {
+		return json.NewEncoder(w).Encode(v)
+	})
+}
+
+// XMLEncoder encodes values by encoding/xml with XML header.
+func XMLEncoder() Encoder {
+	return EncoderFunc(func(w io.Writer, v interface{}) error {
+		if _, err := io.WriteString(w, xml.Header); err != nil {
+			return err
+		}
+
+		return xml.NewEncoder(w).Encode(v)
+	})
+}
+
+// TextEncoder encodes values by fmt.Fprint.
+func TextEncoder() Encoder {
+	return EncoderFunc(func(w io.Writer, v interface{}) error {
+		_, err := fmt.Fprint(w, v)
+
+		return err
+	})
+}
+
+// HTMLEncoder encodes values by the html/template.
+func HTMLEncoder(tmpl *template.Template) Encoder {
+	return EncoderFunc(func(w io.Writer, v interface{}) error {
+		return tmpl.Execute(w, v)
+	})
+}
+
+// CSVEncoder encodes [][]string or slices of structs by encoding/csv.
+// The first record of a slice of structs contains names of exported fields or their "csv" tags,
+// fields with "-" tag are skipped. Values are formatted by fmt.Sprint.
+// CSVValueErr is returned for other values.
+func CSVEncoder() Encoder {
+	return EncoderFunc(func(w io.Writer, v interface{}) error {
+		cw := csv.NewWriter(w)
+
+		if records, ok := v.([][]string); ok {
+			return cw.WriteAll(records)
+		}
+
+		records, err := csvRecords(v)
+		if err != nil {
+			return err
+		}
+
+		return cw.WriteAll(records)
+	})
+}
+
+// csvRecords converts slice of structs to CSV records with header.
+func csvRecords(v interface{}) ([][]string, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, CSVValueErr{Msg: CSVValueErrMsg, Type: fmt.Sprintf("%T", v)}
+	}
+
+	et := rv.Type().Elem()
+	if et.Kind() == reflect.Ptr {
+		et = et.Elem()
+	}
+
+	if et.Kind() != reflect.Struct {
+		return nil, CSVValueErr{Msg: CSVValueErrMsg, Type: fmt.Sprintf("%T", v)}
+	}
+
+	var (
+		fields []int
+		header []string
+	)
+
+	for i := 0; i < et.NumField(); i++ {
+		field := et.Field(i)
+
+		name := field.Tag.Get(csvTag)
+		if field.PkgPath != "" || name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fields = append(fields, i)
+		header = append(header, name)
+	}
+
+	records := make([][]string, 0, rv.Len()+1)
+	records = append(records, header)
+
+	for i := 0; i < rv.Len(); i++ {
+		ev := reflect.Indirect(rv.Index(i))
+		record := make([]string, len(fields))
+
+		if ev.IsValid() {
+			for j, fid := range fields {
+				record[j] = fmt.Sprint(ev.Field(fid).Interface())
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,85 @@
+package mimeheader_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+func TestCSVEncoder(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerCSVEncoder() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			err := mimeheader.CSVEncoder().Encode(&buf, prov.value)
+			if reflect.TypeOf(err) != reflect.TypeOf(prov.expErr) {
+				t.Fatalf("Unexpected error.\nExpected: %T\nActual: %v", prov.expErr, err)
+			}
+
+			if act := buf.String(); act != prov.exp {
+				t.Fatalf("Unexpected CSV.\nExpected: %q\nActual: %q", prov.exp, act)
+			}
+		})
+	}
+}
+
+type csvEncoder struct {
+	name   string
+	value  interface{}
+	exp    string
+	expErr error
+}
+
+type csvRow struct {
+	ID       int `csv:"id"`
+	Name     string
+	Password string `csv:"-"`
+	internal bool
+}
+
+func providerCSVEncoder() []csvEncoder {
+	return []csvEncoder{
+		{
+			name:  "Records",
+			value: [][]string{{"a", "b"}, {"1", "2,3"}},
+			exp:   "a,b\n1,\"2,3\"\n",
+		},
+		{
+			name:  "Empty slice of structs",
+			value: []csvRow{},
+			exp:   "id,Name\n",
+		},
+		{
+			name:  "Slice of structs",
+			value: []csvRow{{ID: 1, Name: "Ann", Password: "secret", internal: true}, {ID: 2, Name: "Bob"}},
+			exp:   "id,Name\n1,Ann\n2,Bob\n",
+		},
+		{
+			name:  "Pointer to array of pointers",
+			value: &[2]*csvRow{{ID: 1, Name: "Ann"}, nil},
+			exp:   "id,Name\n1,Ann\n,\n",
+		},
+		{
+			name:   "Struct",
+			value:  csvRow{ID: 1},
+			expErr: mimeheader.CSVValueErr{},
+		},
+		{
+			name:   "Slice of strings",
+			value:  []string{"a"},
+			expErr: mimeheader.CSVValueErr{},
+		},
+		{
+			name:   "Nil",
+			value:  nil,
+			expErr: mimeheader.CSVValueErr{},
+		},
+	}
+}
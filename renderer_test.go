@@ -0,0 +1,269 @@
+package mimeheader_test
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+type renderUser struct {
+	Name string `json:"name" xml:"name" csv:"name"`
+	Age  int    `json:"age" xml:"age" csv:"age"`
+}
+
+func (u renderUser) String() string {
+	return u.Name + " (" + fmt.Sprint(u.Age) + ")"
+}
+
+func newTestRenderer(opts mimeheader.RendererOptions) *mimeheader.Renderer {
+	rr, err := mimeheader.NewRenderer(opts)
+	if err != nil {
+		panic(err)
+	}
+
+	tmpl := template.Must(template.New("user").Parse("<p>{{.Name}}</p>"))
+
+	for _, reg := range []struct {
+		mtype string
+		enc   mimeheader.Encoder
+	}{
+		{mtype: "application/json", enc: mimeheader.JSONEncoder()},
+		{mtype: "application/xml;qs=0.9", enc: mimeheader.XMLEncoder()},
+		{mtype: "text/html", enc: mimeheader.HTMLEncoder(tmpl)},
+		{mtype: "text/plain;qs=0.5", enc: mimeheader.TextEncoder()},
+		{mtype: "text/csv;header=present", enc: mimeheader.CSVEncoder()},
+	} {
+		// The default type is registered by NewRenderer.
+		if reg.mtype == opts.Default {
+			continue
+		}
+
+		if err := rr.Register(reg.mtype, reg.enc); err != nil {
+			panic(err)
+		}
+	}
+
+	return rr
+}
+
+func ExampleRenderer() {
+	rr := newTestRenderer(mimeheader.RendererOptions{Default: "application/json", DefaultEncoder: mimeheader.JSONEncoder()})
+
+	for _, accept := range []string{"text/*", "image/png", "application/*"} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept", accept)
+
+		rw := httptest.NewRecorder()
+		if err := rr.Render(rw, r, renderUser{Name: "Ann", Age: 30}); err != nil {
+			panic(err)
+		}
+
+		fmt.Println(rw.Header().Get("Content-Type"))
+		fmt.Println(rw.Body.String())
+	}
+	// Output:
+	// text/html; charset=utf-8
+	// <p>Ann</p>
+	// application/json
+	// {"name":"Ann","age":30}
+	//
+	// application/json
+	// {"name":"Ann","age":30}
+}
+
+func TestRenderer_Render(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerRendererRender() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			rr := newTestRenderer(prov.opts)
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if prov.accept != "" {
+				r.Header.Set("Accept", prov.accept)
+			}
+
+			rw := httptest.NewRecorder()
+
+			err := rr.Render(rw, r, prov.value)
+			if reflect.TypeOf(err) != reflect.TypeOf(prov.expErr) {
+				t.Fatalf("Unexpected error.\nExpected: %T\nActual: %v", prov.expErr, err)
+			}
+
+			if rw.Code != prov.expCode {
+				t.Errorf("Unexpected status code.\nExpected: %d\nActual: %d", prov.expCode, rw.Code)
+			}
+
+			if act := rw.Header().Get("Content-Type"); act != prov.expContentType {
+				t.Errorf("Unexpected Content-Type.\nExpected: %s\nActual: %s", prov.expContentType, act)
+			}
+
+			if act := rw.Header().Get("Vary"); act != "Accept" {
+				t.Errorf("Unexpected Vary.\nExpected: Accept\nActual: %s", act)
+			}
+
+			if act := rw.Body.String(); act != prov.expBody {
+				t.Errorf("Unexpected body.\nExpected: %q\nActual: %q", prov.expBody, act)
+			}
+		})
+	}
+}
+
+func TestRenderer_Register(t *testing.T) {
+	t.Parallel()
+
+	rr, err := mimeheader.NewRenderer(mimeheader.RendererOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mtype := range []string{"text/", "text/plain;qs=5"} {
+		err := rr.Register(mtype, mimeheader.TextEncoder())
+
+		var offerErr mimeheader.OfferErr
+		if !errors.As(err, &offerErr) || offerErr.Offer != mtype {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if types := rr.Types(); len(types) != 0 {
+		t.Fatalf("Invalid type was registered: %v", types)
+	}
+}
+
+func TestNewRendererInvalidDefault(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range []struct {
+		opts   mimeheader.RendererOptions
+		expMsg string
+		expErr string
+	}{
+		{
+			opts:   mimeheader.RendererOptions{Default: "text/", DefaultEncoder: mimeheader.TextEncoder()},
+			expMsg: mimeheader.InvalidDefaultErrMsg,
+			expErr: `invalid default mime type "text/": `,
+		},
+		{
+			opts:   mimeheader.RendererOptions{Default: "text/plain;qs=abc", DefaultEncoder: mimeheader.TextEncoder()},
+			expMsg: mimeheader.InvalidDefaultErrMsg,
+			expErr: `invalid default mime type "text/plain;qs=abc": invalid source quality "abc"`,
+		},
+		{
+			opts:   mimeheader.RendererOptions{Default: "text/plain"},
+			expMsg: mimeheader.DefaultEncoderErrMsg,
+			expErr: `default mime type has no encoder "text/plain"`,
+		},
+	} {
+		_, err := mimeheader.NewRenderer(prov.opts)
+
+		var offerErr mimeheader.OfferErr
+		if !errors.As(err, &offerErr) || offerErr.Msg != prov.expMsg || offerErr.Offer != prov.opts.Default {
+			t.Fatalf("Unexpected error for %q: %v", prov.opts.Default, err)
+		}
+
+		if !strings.HasPrefix(err.Error(), prov.expErr) {
+			t.Fatalf("Unexpected error message.\nExpected prefix: %s\nActual: %s", prov.expErr, err.Error())
+		}
+	}
+}
+
+type rendererRender struct {
+	name           string
+	opts           mimeheader.RendererOptions
+	accept         string
+	value          interface{}
+	expErr         error
+	expCode        int
+	expContentType string
+	expBody        string
+}
+
+func providerRendererRender() []rendererRender {
+	users := []renderUser{{Name: "Ann", Age: 30}, {Name: "Bob, Jr.", Age: 7}}
+
+	return []rendererRender{
+		{
+			name:           "Missing Accept header",
+			value:          users[0],
+			expCode:        http.StatusOK,
+			expContentType: "application/json",
+			expBody:        "{\"name\":\"Ann\",\"age\":30}\n",
+		},
+		{
+			name:           "XML",
+			accept:         "application/xml, application/json;q=0.8",
+			value:          users[0],
+			expCode:        http.StatusOK,
+			expContentType: "application/xml; charset=utf-8",
+			expBody:        "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<renderUser><name>Ann</name><age>30</age></renderUser>",
+		},
+		{
+			name:           "Text",
+			accept:         "text/plain",
+			value:          users[0],
+			expCode:        http.StatusOK,
+			expContentType: "text/plain; charset=utf-8",
+			expBody:        "Ann (30)",
+		},
+		{
+			name:           "CSV",
+			accept:         "text/csv",
+			value:          users,
+			expCode:        http.StatusOK,
+			expContentType: "text/csv; charset=utf-8; header=present",
+			expBody:        "name,age\nAnn,30\n\"Bob, Jr.\",7\n",
+		},
+		{
+			name:    "Encoding error",
+			accept:  "text/csv",
+			value:   users[0],
+			expErr:  mimeheader.EncodeErr{},
+			expCode: http.StatusOK,
+		},
+		{
+			name:           "Not acceptable",
+			accept:         "image/*",
+			value:          users[0],
+			expErr:         mimeheader.NotAcceptableErr{},
+			expCode:        http.StatusNotAcceptable,
+			expContentType: "text/plain; charset=utf-8",
+			expBody:        "application/json\napplication/xml;qs=0.9\ntext/html\ntext/plain;qs=0.5\ntext/csv;header=present\n",
+		},
+		{
+			name:           "Earlier range on equal quality",
+			accept:         "text/csv, text/html",
+			value:          users,
+			expCode:        http.StatusOK,
+			expContentType: "text/csv; charset=utf-8; header=present",
+			expBody:        "name,age\nAnn,30\n\"Bob, Jr.\",7\n",
+		},
+		{
+			name:           "First registered type on equal range",
+			accept:         "text/*",
+			value:          users[0],
+			expCode:        http.StatusOK,
+			expContentType: "text/html; charset=utf-8",
+			expBody:        "<p>Ann</p>",
+		},
+		{
+			name:           "Default",
+			opts:           mimeheader.RendererOptions{Default: "text/plain;qs=0.5", DefaultEncoder: mimeheader.TextEncoder()},
+			accept:         "image/*",
+			value:          users[0],
+			expCode:        http.StatusOK,
+			expContentType: "text/plain; charset=utf-8",
+			expBody:        "Ann (30)",
+		},
+	}
+}
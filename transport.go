@@ -0,0 +1,105 @@
+package mimeheader
+
+import "net/http"
+
+// UnacceptableContentTypeErrMsg is a message of UnacceptableContentTypeErr.
+const UnacceptableContentTypeErrMsg = "response content type is not acceptable"
+
+// TransportOptions configures Transport.
+type TransportOptions struct {
+	// Base transport sends requests, http.DefaultTransport is used if it's nil.
+	Base http.RoundTripper
+	// Accept header is set on requests without Accept header, like "application/json, */*;q=0.1".
+	// Request without Accept header accepts any mime type, if it's empty.
+	Accept string
+	// Modes are used to match Content-Type of a response, they have the same meaning as for MimeType.Match.
+	Modes []MatchMode
+	// OnUnacceptable is called for a response with not acceptable Content-Type instead of returning the error.
+	// The response is returned as is, if the hook returns nil, otherwise its body is closed and the error is returned.
+	OnUnacceptable func(resp *http.Response, err UnacceptableContentTypeErr) error
+}
+
+// Transport is http.RoundTripper which sets Accept header of requests and verifies Content-Type of responses.
+// Content-Type of a successful (2xx) response MUST be acceptable by Accept header of the request,
+// otherwise UnacceptableContentTypeErr is returned, like for an HTML error page returned with 200 OK to a JSON client.
+// Responses without content (204 No Content, 205 Reset Content or empty body without Content-Type) are not verified.
+// Transport is safe for concurrent use.
+type Transport struct {
+	base http.RoundTripper
+	opts TransportOptions
+}
+
+// NewTransport creates Transport with the options.
+func NewTransport(opts TransportOptions) *Transport {
+	base := opts.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &Transport{base: base, opts: opts}
+}
+
+// RoundTrip implements http.RoundTripper. The request is cloned before setting Accept header.
+func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if t.opts.Accept != "" && r.Header.Get(HeaderAccept) == "" {
+		r = r.Clone(r.Context())
+		r.Header.Set(HeaderAccept, t.opts.Accept)
+	}
+
+	resp, err := t.base.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+
+	uerr, ok := t.verify(r, resp)
+	if ok {
+		return resp, nil
+	}
+
+	if t.opts.OnUnacceptable != nil {
+		err = t.opts.OnUnacceptable(resp, uerr)
+	} else {
+		err = uerr
+	}
+
+	if err != nil {
+		resp.Body.Close()
+
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// verify checks Content-Type of the response by Accept header of the request.
+func (t *Transport) verify(r *http.Request, resp *http.Response) (UnacceptableContentTypeErr, bool) {
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices ||
+		resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusResetContent {
+		return UnacceptableContentTypeErr{}, true
+	}
+
+	ctype := resp.Header.Get(HeaderContentType)
+	if ctype == "" && resp.ContentLength == 0 {
+		return UnacceptableContentTypeErr{}, true
+	}
+
+	accept := acceptHeader(r)
+	uerr := UnacceptableContentTypeErr{
+		Msg:        UnacceptableContentTypeErrMsg,
+		MimeType:   ctype,
+		Accept:     accept,
+		StatusCode: resp.StatusCode,
+	}
+
+	if _, err := parseContentType(ctype); err != nil {
+		uerr.Err = err
+
+		return uerr, false
+	}
+
+	if !ParseAcceptHeader(accept).Match(ctype, t.opts.Modes...) {
+		return uerr, false
+	}
+
+	return UnacceptableContentTypeErr{}, true
+}
@@ -0,0 +1,265 @@
+package mimeheader_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aohorodnyk/mimeheader"
+)
+
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func ExampleTransport() {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(rw, "<html>Service is unavailable</html>")
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: mimeheader.NewTransport(mimeheader.TransportOptions{
+		Accept: "application/json",
+	})}
+
+	resp, err := client.Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+	}
+
+	var uerr mimeheader.UnacceptableContentTypeErr
+	fmt.Println(errors.As(err, &uerr), uerr.StatusCode, uerr.MimeType)
+	// Output:
+	// true 200 text/html
+}
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	for _, prov := range providerTransport() {
+		prov := prov
+		t.Run(prov.name, func(t *testing.T) {
+			t.Parallel()
+
+			var sentAccept string
+
+			body := &closeTracker{Reader: strings.NewReader(prov.body)}
+			opts := prov.opts
+			opts.Base = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				sentAccept = r.Header.Get("Accept")
+
+				resp := &http.Response{
+					StatusCode:    prov.status,
+					Header:        http.Header{},
+					Body:          body,
+					ContentLength: int64(len(prov.body)),
+				}
+				if prov.ctype != "" {
+					resp.Header.Set("Content-Type", prov.ctype)
+				}
+
+				return resp, nil
+			})
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if prov.accept != "" {
+				r.Header.Set("Accept", prov.accept)
+			}
+
+			resp, err := mimeheader.NewTransport(opts).RoundTrip(r)
+			if sentAccept != prov.expAccept {
+				t.Fatalf("Wrong sent Accept header.\nExpected: %s\nActual: %s", prov.expAccept, sentAccept)
+			}
+
+			if r.Header.Get("Accept") != prov.accept {
+				t.Fatalf("Original request was modified: %s", r.Header.Get("Accept"))
+			}
+
+			var uerr mimeheader.UnacceptableContentTypeErr
+			if errors.As(err, &uerr) != prov.expErr {
+				t.Fatalf("Unexpected error.\nExpected: %t\nActual: %v", prov.expErr, err)
+			}
+
+			if (resp == nil) != (err != nil) || body.closed != (err != nil) {
+				t.Fatalf("Wrong response %v or closed body %t with error %v", resp, body.closed, err)
+			}
+		})
+	}
+}
+
+func TestTransportOnUnacceptable(t *testing.T) {
+	t.Parallel()
+
+	base := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"text/html; charset=utf-8"}},
+			Body:       io.NopCloser(strings.NewReader("<html></html>")),
+		}, nil
+	})
+
+	var hooked mimeheader.UnacceptableContentTypeErr
+
+	transport := mimeheader.NewTransport(mimeheader.TransportOptions{
+		Base:   base,
+		Accept: "application/json",
+		OnUnacceptable: func(resp *http.Response, err mimeheader.UnacceptableContentTypeErr) error {
+			hooked = err
+
+			return nil
+		},
+	})
+
+	resp, err := transport.RoundTrip(httptest.NewRequest(http.MethodGet, "/", nil))
+	if err != nil || resp == nil {
+		t.Fatalf("Response must be returned by the hook, got error: %v", err)
+	}
+
+	resp.Body.Close()
+
+	exp := mimeheader.UnacceptableContentTypeErr{
+		Msg:        mimeheader.UnacceptableContentTypeErrMsg,
+		MimeType:   "text/html; charset=utf-8",
+		Accept:     "application/json",
+		StatusCode: http.StatusOK,
+	}
+	if hooked != exp {
+		t.Fatalf("Wrong error passed to the hook.\nExpected: %#v\nActual: %#v", exp, hooked)
+	}
+
+	expMsg := `response content type is not acceptable "text/html; charset=utf-8", accepted: "application/json"`
+	if hooked.Error() != expMsg {
+		t.Fatalf("Wrong error message.\nExpected: %s\nActual: %s", expMsg, hooked.Error())
+	}
+}
+
+type closeTracker struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+
+	return nil
+}
+
+type transport struct {
+	name      string
+	opts      mimeheader.TransportOptions
+	accept    string
+	status    int
+	ctype     string
+	body      string
+	expAccept string
+	expErr    bool
+}
+
+func providerTransport() []transport {
+	return []transport{
+		{
+			name:      "Acceptable type",
+			opts:      mimeheader.TransportOptions{Accept: "application/json, */*;q=0.1"},
+			status:    http.StatusOK,
+			ctype:     "text/plain",
+			body:      "ok",
+			expAccept: "application/json, */*;q=0.1",
+		},
+		{
+			name:      "HTML page for JSON client",
+			opts:      mimeheader.TransportOptions{Accept: "application/json"},
+			status:    http.StatusOK,
+			ctype:     "text/html",
+			body:      "<html></html>",
+			expAccept: "application/json",
+			expErr:    true,
+		},
+		{
+			name:      "Accept of request is kept",
+			opts:      mimeheader.TransportOptions{Accept: "application/json"},
+			accept:    "text/html",
+			status:    http.StatusOK,
+			ctype:     "text/html",
+			body:      "<html></html>",
+			expAccept: "text/html",
+		},
+		{
+			name:      "Without Accept",
+			status:    http.StatusOK,
+			ctype:     "text/html",
+			body:      "<html></html>",
+			expAccept: "",
+		},
+		{
+			name:      "Suffix mode",
+			opts:      mimeheader.TransportOptions{Accept: "application/json", Modes: []mimeheader.MatchMode{mimeheader.MatchSuffix}},
+			status:    http.StatusCreated,
+			ctype:     "application/problem+json",
+			body:      "{}",
+			expAccept: "application/json",
+		},
+		{
+			name:      "Params exact mode",
+			opts:      mimeheader.TransportOptions{Accept: "text/plain;charset=utf-8", Modes: []mimeheader.MatchMode{mimeheader.MatchParamsExact}},
+			status:    http.StatusOK,
+			ctype:     "text/plain; charset=iso-8859-1",
+			body:      "ok",
+			expAccept: "text/plain;charset=utf-8",
+			expErr:    true,
+		},
+		{
+			name:      "Invalid Content-Type",
+			opts:      mimeheader.TransportOptions{Accept: "*/*"},
+			status:    http.StatusOK,
+			ctype:     "json",
+			body:      "{}",
+			expAccept: "*/*",
+			expErr:    true,
+		},
+		{
+			name:      "Wildcard Content-Type",
+			opts:      mimeheader.TransportOptions{Accept: "application/json"},
+			status:    http.StatusOK,
+			ctype:     "application/*",
+			body:      "{}",
+			expAccept: "application/json",
+			expErr:    true,
+		},
+		{
+			name:      "Missed Content-Type",
+			opts:      mimeheader.TransportOptions{Accept: "application/json"},
+			status:    http.StatusOK,
+			body:      "{}",
+			expAccept: "application/json",
+			expErr:    true,
+		},
+		{
+			name:      "Empty body without Content-Type",
+			opts:      mimeheader.TransportOptions{Accept: "application/json"},
+			status:    http.StatusOK,
+			expAccept: "application/json",
+		},
+		{
+			name:      "No Content",
+			opts:      mimeheader.TransportOptions{Accept: "application/json"},
+			status:    http.StatusNoContent,
+			ctype:     "text/html",
+			expAccept: "application/json",
+		},
+		{
+			name:      "Error status is not verified",
+			opts:      mimeheader.TransportOptions{Accept: "application/json"},
+			status:    http.StatusBadGateway,
+			ctype:     "text/html",
+			body:      "<html></html>",
+			expAccept: "application/json",
+		},
+	}
+}
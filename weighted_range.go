@@ -0,0 +1,94 @@
+package mimeheader
+
+import "sort"
+
+// weightedRange is a range of Accept-Encoding, Accept-Language or Accept-Charset header with quality.
+type weightedRange[R any] interface {
+	Valid() bool
+	Match(value string) bool
+	// weight returns quality of the range.
+	weight() float32
+	// lessSpecific reports whether the range is less specific than the other one.
+	lessSpecific(other R) bool
+}
+
+// validRanges returns valid ranges sorted by precedence.
+func validRanges[R weightedRange[R]](rs []R) []R {
+	ranges := make([]R, 0, len(rs))
+
+	for _, r := range rs {
+		if r.Valid() {
+			ranges = append(ranges, r)
+		}
+	}
+
+	sortRanges(ranges)
+
+	return ranges
+}
+
+// sortRanges sorts ranges by quality, then by specificity, both descending.
+// Ranges with equal precedence keep their order.
+func sortRanges[R weightedRange[R]](ranges []R) {
+	sort.SliceStable(ranges, func(i, j int) bool {
+		if ranges[i].weight() != ranges[j].weight() {
+			return ranges[i].weight() > ranges[j].weight()
+		}
+
+		return ranges[j].lessSpecific(ranges[i])
+	})
+}
+
+// negotiateRange returns index of the range and the value with the highest precedence
+// from values matched with non-zero quality, or -1 if nothing is acceptable.
+// Quality of every value is taken from the most specific matched range.
+func negotiateRange[R weightedRange[R]](ranges []R, values []string) (int, string) {
+	rid, value := -1, ""
+
+	for _, v := range values {
+		id := mostSpecificRange(ranges, v)
+		if id < 0 || ranges[id].weight() <= 0 {
+			continue
+		}
+
+		if rid < 0 || preferredWeighted(ranges, id, rid) {
+			rid, value = id, v
+		}
+	}
+
+	return rid, value
+}
+
+// mostSpecificRange returns index of the most specific range matched the value or -1 if nothing matched.
+func mostSpecificRange[R weightedRange[R]](ranges []R, value string) int {
+	rid := -1
+
+	for id, r := range ranges {
+		if !r.Match(value) {
+			continue
+		}
+
+		if rid < 0 || ranges[rid].lessSpecific(r) {
+			rid = id
+		}
+	}
+
+	return rid
+}
+
+// preferredWeighted reports whether range i has higher precedence than range j.
+func preferredWeighted[R weightedRange[R]](ranges []R, i, j int) bool {
+	if ranges[i].weight() != ranges[j].weight() {
+		return ranges[i].weight() > ranges[j].weight()
+	}
+
+	if ranges[j].lessSpecific(ranges[i]) {
+		return true
+	}
+
+	if ranges[i].lessSpecific(ranges[j]) {
+		return false
+	}
+
+	return i < j
+}